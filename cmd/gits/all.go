@@ -6,17 +6,34 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli/add"
+	"github.com/rafi/gits/internal/cli/apply"
+	"github.com/rafi/gits/internal/cli/branch"
 	"github.com/rafi/gits/internal/cli/browse"
+	"github.com/rafi/gits/internal/cli/bundle"
 	"github.com/rafi/gits/internal/cli/cd"
 	"github.com/rafi/gits/internal/cli/checkout"
 	"github.com/rafi/gits/internal/cli/clone"
+	"github.com/rafi/gits/internal/cli/commit"
+	"github.com/rafi/gits/internal/cli/exec"
 	"github.com/rafi/gits/internal/cli/fetch"
+	"github.com/rafi/gits/internal/cli/forks"
+	"github.com/rafi/gits/internal/cli/grep"
 	"github.com/rafi/gits/internal/cli/list"
+	"github.com/rafi/gits/internal/cli/mirror"
+	"github.com/rafi/gits/internal/cli/open"
 	"github.com/rafi/gits/internal/cli/orphan"
+	"github.com/rafi/gits/internal/cli/prune"
 	"github.com/rafi/gits/internal/cli/pull"
+	"github.com/rafi/gits/internal/cli/push"
+	"github.com/rafi/gits/internal/cli/relocate"
+	"github.com/rafi/gits/internal/cli/rm"
 	"github.com/rafi/gits/internal/cli/status"
 	"github.com/rafi/gits/internal/cli/sync"
+	"github.com/rafi/gits/internal/cli/tag"
+	"github.com/rafi/gits/internal/cli/timeline"
+	"github.com/rafi/gits/internal/cli/worktree"
 	"github.com/rafi/gits/internal/types"
 	"github.com/rafi/gits/internal/version"
 )
@@ -27,27 +44,199 @@ const (
 	appLong  = `Fast CLI Git manager for multiple repositories grouped by projects, with GitHub/GitLab/Bitbucket support.`
 )
 
-var listOutput = "table"
+var (
+	listOutput      = "table"
+	applyOptions    = apply.Options{}
+	branchOptions   = branch.Options{}
+	bundleOptions   = bundle.Options{}
+	cloneOptions    = domain.CloneOptions{}
+	commitOptions   = commit.Options{}
+	execOptions     = exec.Options{}
+	forksOptions    = forks.Options{}
+	grepOptions     = grep.Options{}
+	logOptions      = timeline.Options{}
+	mirrorOptions   = mirror.Options{}
+	openOptions     = open.Options{}
+	pruneOptions    = prune.Options{}
+	pullOptions     = domain.PullOptions{}
+	pushOptions     = push.Options{}
+	relocateOptions = relocate.Options{}
+	rmOptions       = rm.Options{}
+	statusOptions   = status.Options{}
+	tagOptions      = tag.Options{}
+)
 
 func init() {
 	listCmd.
 		PersistentFlags().
 		StringVarP(&listOutput, "output", "o", listOutput, "output style (json, name, table, tree, wide)")
 
+	applyCmd.Flags().
+		StringVar(&applyOptions.Script, "script", "", "command run by the shell in each repository, instead of a patch")
+	applyCmd.Flags().
+		StringVarP(&applyOptions.Branch, "branch", "b", "", "branch created from default branch for the change")
+	applyCmd.Flags().
+		StringVarP(&applyOptions.Message, "message", "m", "", "commit message (default \"Apply <patch>\" or \"Run <script>\")")
+	_ = applyCmd.MarkFlagRequired("branch")
+
+	branchCreateCmd.Flags().
+		StringVar(&branchOptions.Base, "base", "", "start point of the branch (default each repository origin default branch, after fetch)")
+	branchDeleteCmd.Flags().
+		BoolVarP(&branchOptions.Force, "force", "f", false, "delete branches even if not fully merged")
+
+	bundleCmd.Flags().
+		StringVar(&bundleOptions.Dir, "out", "", "bundles directory, bundles are kept per project")
+	bundleCmd.Flags().
+		StringSliceVar(&bundleOptions.Refs, "ref", nil, "refs to bundle (default all)")
+	_ = bundleCmd.MarkFlagRequired("out")
+
+	cloneCmd.Flags().
+		IntVar(&cloneOptions.Depth, "depth", 0, "truncate history to the specified number of commits")
+	cloneCmd.Flags().
+		StringVar(&cloneOptions.Filter, "filter", "", "partial clone object filter (e.g. blob:none)")
+	cloneCmd.Flags().
+		BoolVar(&cloneOptions.SingleBranch, "single-branch", false, "clone only the history of one branch")
+	cloneCmd.Flags().
+		StringVarP(&cloneOptions.Branch, "branch", "b", "", "branch to checkout instead of remote HEAD")
+	cloneCmd.Flags().
+		StringVar(&cloneOptions.Reference, "reference", "", "local repository to borrow objects from, if it exists")
+
+	commitCmd.Flags().
+		StringVarP(&commitOptions.Message, "message", "m", "", "commit message, shared by all repositories")
+	commitCmd.Flags().
+		BoolVarP(&commitOptions.All, "all", "a", false, "commit all dirty repositories, without selecting them")
+	commitCmd.Flags().
+		BoolVar(&commitOptions.Push, "push", false, "push commits to upstream")
+	_ = commitCmd.MarkFlagRequired("message")
+
+	execCmd.Flags().
+		BoolVar(&execOptions.Serial, "serial", false, "run in one repository at a time, streaming output")
+	execCmd.Flags().
+		BoolVar(&execOptions.OnlyDirty, "only-dirty", false, "run only in repositories with local changes")
+	execCmd.Flags().
+		BoolVar(&execOptions.OnlyCloned, "only-cloned", false, "skip repositories that are not cloned")
+	execCmd.Flags().
+		BoolVar(&execOptions.FailFast, "fail-fast", false, "stop after the first failure")
+
+	grepCmd.Flags().
+		StringVar(&grepOptions.Ref, "ref", "", "search a branch, tag or commit instead of the working tree")
+	grepCmd.Flags().
+		BoolVarP(&grepOptions.FilesOnly, "files-with-matches", "l", false, "show only names of matching files")
+	grepCmd.Flags().
+		BoolVarP(&grepOptions.Finder, "interactive", "i", false, "select a match with fzf, and open it in $EDITOR")
+
+	logCmd.Flags().
+		StringVar(&logOptions.Since, "since", "1w", "show commits since duration (12h, 3d, 1w, 2m, 1y) or date")
+	logCmd.Flags().
+		StringVar(&logOptions.Author, "author", "", "filter by author name or email, 'me' for your user email")
+	logCmd.Flags().
+		BoolVar(&logOptions.Branches, "branches", false, "include commits of all local branches")
+	logCmd.Flags().
+		StringVarP((*string)(&logOptions.Format), "format", "f", "text", "output format (text, markdown)")
+
+	mirrorCmd.Flags().
+		StringVar(&mirrorOptions.Dest, "dest", "", "backup directory, mirrors are kept per project")
+	_ = mirrorCmd.MarkFlagRequired("dest")
+
+	openCmd.Flags().
+		BoolVar(&openOptions.Branch, "branch", false, "open the current branch")
+	openCmd.Flags().
+		BoolVar(&openOptions.Commits, "commits", false, "open the commits of the current branch")
+	openCmd.Flags().
+		BoolVar(&openOptions.Pulls, "prs", false, "open pull or merge requests")
+	openCmd.Flags().
+		BoolVar(&openOptions.CI, "ci", false, "open CI pipelines")
+	openCmd.Flags().
+		BoolVarP(&openOptions.Print, "print", "p", false, "print the URL, without opening a browser")
+	openCmd.MarkFlagsMutuallyExclusive("branch", "commits", "prs", "ci")
+
+	unbundleCmd.Flags().
+		StringVar(&bundleOptions.Dir, "from", "", "bundles directory created by bundle command")
+	_ = unbundleCmd.MarkFlagRequired("from")
+
+	pruneBranchesCmd.Flags().
+		BoolVar(&pruneOptions.DryRun, "dry-run", false, "list prunable branches, without deleting them")
+	pruneBranchesCmd.Flags().
+		BoolVarP(&pruneOptions.Finder, "interactive", "i", false, "select branches to delete with fzf")
+	pruneBranchesCmd.Flags().
+		BoolVarP(&pruneOptions.Force, "force", "f", false, "delete gone branches even with unpushed commits")
+
+	pullCmd.Flags().
+		StringVarP((*string)(&pullOptions.Strategy), "strategy", "s", "", "pull strategy (ff-only, rebase, merge)")
+	pullCmd.Flags().
+		BoolVar(&pullOptions.Autostash, "autostash", false, "stash local changes before pull, and restore them after")
+
+	pushCmd.Flags().
+		BoolVar(&pushOptions.DryRun, "dry-run", false, "show what would be pushed, without pushing")
+	pushCmd.Flags().
+		BoolVarP(&pushOptions.SetUpstream, "set-upstream", "u", false, "push branches without upstream or with a gone upstream, and set it")
+
+	relocateCmd.Flags().
+		BoolVar(&relocateOptions.DryRun, "dry-run", false, "list clones to move, without moving them")
+	relocateCmd.Flags().
+		StringSliceVar(&relocateOptions.From, "from", nil, "extra directories to scan for clones, e.g. previous project path")
+
+	rmCmd.Flags().
+		BoolVarP(&rmOptions.Force, "force", "f", false, "remove even if uncommitted or unpushed work would be lost")
+	rmCmd.Flags().
+		StringVar(&rmOptions.Archive, "archive", "", "save a tar.gz of the clone in directory, before removal")
+
+	syncForksCmd.Flags().
+		BoolVar(&forksOptions.Push, "push", false, "push synchronized default branch to origin")
+
+	statusCmd.Flags().
+		BoolVar(&statusOptions.Cached, "cached", false, "render last saved snapshots and refresh them in background")
+	statusCmd.Flags().
+		BoolVar(&statusOptions.Refresh, "refresh", false, "save snapshots without rendering (all projects if none given)")
+
+	tagCmd.Flags().
+		StringVarP(&tagOptions.Message, "message", "m", "", "tag message (default \"Release <version>\")")
+	tagCmd.Flags().
+		BoolVarP(&tagOptions.Sign, "sign", "s", false, "create GPG-signed tags")
+	tagCmd.Flags().
+		BoolVar(&tagOptions.Push, "push", false, "push tags to upstream remote")
+	tagCmd.Flags().
+		BoolVar(&tagOptions.Check, "check", false, "report repositories missing the tag, without tagging")
+
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(branchCmd)
 	rootCmd.AddCommand(branchOverviewCmd)
 	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(bundleCmd)
 	rootCmd.AddCommand(cdCmd)
 	rootCmd.AddCommand(checkoutCmd)
 	rootCmd.AddCommand(cloneCmd)
+	rootCmd.AddCommand(commitCmd)
+	rootCmd.AddCommand(execCmd)
 	rootCmd.AddCommand(fetchCmd)
+	rootCmd.AddCommand(grepCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(mirrorCmd)
+	rootCmd.AddCommand(openCmd)
 	rootCmd.AddCommand(orphanCmd)
+	rootCmd.AddCommand(pruneBranchesCmd)
 	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(pushCmd)
+	rootCmd.AddCommand(relocateCmd)
 	rootCmd.AddCommand(repoOverviewCmd)
+	rootCmd.AddCommand(rmCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(syncForksCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(unbundleCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(worktreeCmd)
+
+	branchCmd.AddCommand(branchCreateCmd)
+	branchCmd.AddCommand(branchDeleteCmd)
+	branchCmd.AddCommand(branchRenameCmd)
+
+	worktreeCmd.AddCommand(worktreeAddCmd)
+	worktreeCmd.AddCommand(worktreeListCmd)
+	worktreeCmd.AddCommand(worktreeRemoveCmd)
 }
 
 var addCmd = &cobra.Command{
@@ -58,6 +247,51 @@ var addCmd = &cobra.Command{
 	RunE:              runWithDeps(add.ExecAdd),
 }
 
+var applyCmd = &cobra.Command{
+	Use:   "apply <patch|--script cmd> --branch <name> [project]",
+	Short: "Apply a patch or script on a new branch, and commit changes",
+	Args:  cobra.MaximumNArgs(2),
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return apply.ExecApply(applyOptions, args, deps)
+	}),
+}
+
+var branchCmd = &cobra.Command{
+	Use:   "branch",
+	Short: "Manage a branch across repositories",
+	Args:  cobra.NoArgs,
+}
+
+var branchCreateCmd = &cobra.Command{
+	Use:               "create <name> [project] [repo]...",
+	Short:             "Create a branch from default branch, or a given base",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePatternProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return branch.ExecCreate(branchOptions, args, deps)
+	}),
+}
+
+var branchDeleteCmd = &cobra.Command{
+	Use:               "delete <name> [project] [repo]...",
+	Short:             "Delete a branch, refusing unmerged branches unless forced",
+	Aliases:           []string{"rm"},
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePatternProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return branch.ExecDelete(branchOptions, args, deps)
+	}),
+}
+
+var branchRenameCmd = &cobra.Command{
+	Use:               "rename <name> <new-name> [project] [repo]...",
+	Short:             "Rename a branch",
+	Aliases:           []string{"mv"},
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeRenameProject,
+	RunE:              runWithDeps(branch.ExecRename),
+}
+
 var branchOverviewCmd = &cobra.Command{
 	Use:               "branch-overview <project> <repo> [branch]",
 	Hidden:            true,
@@ -74,6 +308,16 @@ var browseCmd = &cobra.Command{
 	RunE:              runWithDeps(browse.ExecBrowse),
 }
 
+var bundleCmd = &cobra.Command{
+	Use:               "bundle [project]",
+	Short:             "Export repositories as git bundles for offline transfer",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return bundle.ExecBundle(bundleOptions, args, deps)
+	}),
+}
+
 var cdCmd = &cobra.Command{
 	Use:               "cd [project] [repo]",
 	Short:             "Get repository path",
@@ -96,7 +340,39 @@ var cloneCmd = &cobra.Command{
 	Short:             "Clone all repositories",
 	Args:              cobra.MaximumNArgs(2),
 	ValidArgsFunction: completeProjectRepo,
-	RunE:              runWithDeps(clone.ExecClone),
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return clone.ExecClone(cloneOptions, args, deps)
+	}),
+}
+
+var commitCmd = &cobra.Command{
+	Use:               "commit -m <message> [project]",
+	Short:             "Stage and commit changes of selected dirty repositories",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return commit.ExecCommit(commitOptions, args, deps)
+	}),
+}
+
+var execCmd = &cobra.Command{
+	Use:               "exec [project] [repo] -- <command> [args]...",
+	Short:             "Run a command in project repositories",
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeProjectRepo,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments after dash are the command to run.
+		if dash := cmd.ArgsLenAtDash(); dash > -1 {
+			execOptions.Command = args[dash:]
+			args = args[:dash]
+		}
+		if len(args) > 2 {
+			return fmt.Errorf("accepts at most 2 arg(s) before '--', received %d", len(args))
+		}
+		return runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+			return exec.ExecCommand(execOptions, args, deps)
+		})(cmd, args)
+	},
 }
 
 var fetchCmd = &cobra.Command{
@@ -107,6 +383,16 @@ var fetchCmd = &cobra.Command{
 	RunE:              runWithDeps(fetch.ExecFetch),
 }
 
+var grepCmd = &cobra.Command{
+	Use:               "grep <pattern> [project]",
+	Short:             "Search tracked files across project repositories",
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePatternProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return grep.ExecGrep(grepOptions, args, deps)
+	}),
+}
+
 var listCmd = &cobra.Command{
 	Use:               "list [project]...",
 	Short:             "List project repositories",
@@ -119,6 +405,36 @@ var listCmd = &cobra.Command{
 	}),
 }
 
+var logCmd = &cobra.Command{
+	Use:               "log [project]",
+	Short:             "Show a commit timeline across project repositories",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return timeline.ExecLog(logOptions, args, deps)
+	}),
+}
+
+var mirrorCmd = &cobra.Command{
+	Use:               "mirror [project]",
+	Short:             "Maintain bare mirror clones of project repositories",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return mirror.ExecMirror(mirrorOptions, args, deps)
+	}),
+}
+
+var openCmd = &cobra.Command{
+	Use:               "open [project] [repo]",
+	Short:             "Open repository web page in a browser",
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeProjectRepo,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return open.ExecOpen(openOptions, args, deps)
+	}),
+}
+
 var orphanCmd = &cobra.Command{
 	Use:               "orphan [project] [repo]",
 	Short:             "Finds orphan repository",
@@ -127,12 +443,44 @@ var orphanCmd = &cobra.Command{
 	RunE:              runWithDeps(orphan.ExecOrphan),
 }
 
+var pruneBranchesCmd = &cobra.Command{
+	Use:               "prune-branches [project]",
+	Short:             "Delete local branches merged into default branch, or with upstream gone",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return prune.ExecPruneBranches(pruneOptions, args, deps)
+	}),
+}
+
 var pullCmd = &cobra.Command{
 	Use:               "pull [project] [repo]",
 	Short:             "Pull repository",
 	Args:              cobra.MaximumNArgs(2),
 	ValidArgsFunction: completeProjectRepo,
-	RunE:              runWithDeps(pull.ExecPull),
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return pull.ExecPull(pullOptions, args, deps)
+	}),
+}
+
+var pushCmd = &cobra.Command{
+	Use:               "push [project] [repo]",
+	Short:             "Push repositories ahead of upstream",
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeProjectRepo,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return push.ExecPush(pushOptions, args, deps)
+	}),
+}
+
+var relocateCmd = &cobra.Command{
+	Use:               "relocate [project]",
+	Short:             "Move clones to their configured paths after layout changes",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return relocate.ExecRelocate(relocateOptions, args, deps)
+	}),
 }
 
 var repoOverviewCmd = &cobra.Command{
@@ -143,12 +491,24 @@ var repoOverviewCmd = &cobra.Command{
 	RunE:              runWithDeps(browse.ExecRepoOverview),
 }
 
+var rmCmd = &cobra.Command{
+	Use:               "rm [project] [repo]",
+	Short:             "Remove a local clone, if no local work would be lost",
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeProjectRepo,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return rm.ExecRemove(rmOptions, args, deps)
+	}),
+}
+
 var statusCmd = &cobra.Command{
 	Use:               "status [project] [repo]",
 	Short:             "Show Git repositories short status",
 	Args:              cobra.MaximumNArgs(2),
 	ValidArgsFunction: completeProjectRepo,
-	RunE:              runWithDeps(status.ExecStatus),
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return status.ExecStatus(statusOptions, args, deps)
+	}),
 }
 
 var syncCmd = &cobra.Command{
@@ -159,6 +519,36 @@ var syncCmd = &cobra.Command{
 	RunE:              runWithDeps(sync.ExecSync),
 }
 
+var syncForksCmd = &cobra.Command{
+	Use:               "sync-forks [project] [repo]",
+	Short:             "Fast-forward forks default branch from upstream",
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeProjectRepo,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return forks.ExecSyncForks(forksOptions, args, deps)
+	}),
+}
+
+var tagCmd = &cobra.Command{
+	Use:               "tag <version> [project]",
+	Short:             "Tag a release across clean repositories in sync with upstream",
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePatternProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return tag.ExecTag(tagOptions, args, deps)
+	}),
+}
+
+var unbundleCmd = &cobra.Command{
+	Use:               "unbundle [project]",
+	Short:             "Clone repositories from git bundles",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProject,
+	RunE: runWithDeps(func(args []string, deps types.RuntimeCLI) error {
+		return bundle.ExecUnbundle(bundleOptions, args, deps)
+	}),
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version",
@@ -167,3 +557,35 @@ var versionCmd = &cobra.Command{
 		fmt.Printf("gits %s %s\n", version.GetVersion(), runtime.Version())
 	},
 }
+
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Manage branch worktrees across repositories",
+	Args:  cobra.NoArgs,
+}
+
+var worktreeAddCmd = &cobra.Command{
+	Use:               "add <project> <branch> [repo]...",
+	Short:             "Create branch worktrees in a sibling directory of project",
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeProject,
+	RunE:              runWithDeps(worktree.ExecAdd),
+}
+
+var worktreeListCmd = &cobra.Command{
+	Use:               "list [project] [branch]",
+	Short:             "List worktrees grouped by repository",
+	Aliases:           []string{"ls"},
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeProject,
+	RunE:              runWithDeps(worktree.ExecList),
+}
+
+var worktreeRemoveCmd = &cobra.Command{
+	Use:               "remove <project> <branch> [repo]...",
+	Short:             "Remove branch worktrees without uncommitted changes",
+	Aliases:           []string{"rm"},
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeProject,
+	RunE:              runWithDeps(worktree.ExecRemove),
+}
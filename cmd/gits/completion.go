@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -17,8 +18,14 @@ func completionDeps() (deps types.Runtime, err error) {
 	if err != nil {
 		return deps, err
 	}
+	gitClient, err := git.NewGit()
+	if err != nil {
+		return deps, err
+	}
 	return types.Runtime{
+		Context:    context.Background(),
 		Cache:      cacheClient,
+		Git:        gitClient,
 		Projects:   configFile.Projects,
 		ConfigPath: configFile.Filename,
 	}, nil
@@ -65,6 +72,25 @@ func completeProjectRepo(cmd *cobra.Command, args []string, toComplete string) (
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// completePatternProject returns a list of project names for shell
+// completion, after a search pattern.
+func completePatternProject(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Max 2 args: pattern, project.
+	if len(args) != 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeProject(cmd, args, toComplete)
+}
+
+// completeRenameProject returns a list of project names for shell
+// completion, after a name and a new name.
+func completeRenameProject(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeProject(cmd, args, toComplete)
+}
+
 // completeProjectRepoBranch returns a list of branch names for shell completion.
 func completeProjectRepoBranch(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) < 1 {
@@ -98,15 +124,11 @@ func completeProjectRepoBranch(cmd *cobra.Command, args []string, toComplete str
 
 	// Find branches
 	var completions []string
-	g, err := git.NewGit()
-	if err != nil {
-		return nil, cobra.ShellCompDirectiveError
-	}
-	gitRepo, err := g.Open(repo.AbsPath)
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
-	branches, err := gitRepo.Branches()
+	branches, err := gitRepo.Branches(deps.Context)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
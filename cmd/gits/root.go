@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/mitchellh/go-homedir"
 	log "github.com/sirupsen/logrus"
@@ -69,6 +73,10 @@ func setupLogger(cfg config.File) {
 // runWithDeps execute a command with dependencies.
 func runWithDeps(f func([]string, types.RuntimeCLI) error) cobra.PositionalArgs {
 	return func(_ *cobra.Command, args []string) error {
+		// Cancel running git operations on interrupt.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
 		// Setup runtime dependencies.
 		gitClient, err := git.NewGit()
 		if err != nil {
@@ -88,12 +96,14 @@ func runWithDeps(f func([]string, types.RuntimeCLI) error) cobra.PositionalArgs
 		if err := theme.ParseConfig(configFile.Settings.Theme); err != nil {
 			return err
 		}
+		configFile.Settings.Icons = config.ParseIcons(configFile.Settings.Icons)
 
 		// Run command with dependencies.
 		cmdErr := f(args, types.RuntimeCLI{
 			Theme:   theme,
 			HomeDir: homeDir,
 			Runtime: types.Runtime{
+				Context:    ctx,
 				Projects:   configFile.Projects,
 				Settings:   configFile.Settings,
 				ConfigPath: configFile.Filename,
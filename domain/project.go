@@ -15,18 +15,21 @@ import (
 // Project represents a single project that can have many child projects,
 // while each project can have many repositories.
 type Project struct {
-	Source      *ProviderSource `json:"source,omitempty"`
-	Clone       *bool           `json:"clone,omitempty"`
-	ID          string          `json:"id"`
-	Name        string          `json:"name"`
-	Path        string          `json:"path"`
-	Desc        string          `json:"desc,omitempty"`
-	Hash        string          `json:"-"`
-	AbsPath     string          `json:"-"`
-	Repos       []Repository    `json:"repos,omitempty"`
-	SubProjects []Project       `json:"subprojects,omitempty"`
-	Include     []string        `json:"include,omitempty"`
-	Exclude     []string        `json:"exclude,omitempty"`
+	Source       *ProviderSource `json:"source,omitempty"`
+	Clone        *bool           `json:"clone,omitempty"`
+	Submodules   SubmodulesMode  `json:"submodules,omitempty"`
+	CloneOptions *CloneOptions   `json:"cloneOptions,omitempty"`
+	PullOptions  *PullOptions    `json:"pullOptions,omitempty"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Path         string          `json:"path"`
+	Desc         string          `json:"desc,omitempty"`
+	Hash         string          `json:"-"`
+	AbsPath      string          `json:"-"`
+	Repos        []Repository    `json:"repos,omitempty"`
+	SubProjects  []Project       `json:"subprojects,omitempty"`
+	Include      []string        `json:"include,omitempty"`
+	Exclude      []string        `json:"exclude,omitempty"`
 }
 
 // ProjectListKeyed is a list of projects with name keys.
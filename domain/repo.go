@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -16,6 +17,13 @@ type Repository struct {
 	URL       string `json:"url,omitempty"`
 	Desc      string `json:"desc,omitempty"`
 
+	// Remotes are extra remotes by name, e.g. the upstream of a fork.
+	Remotes map[string]string `json:"remotes,omitempty"`
+
+	Submodules   SubmodulesMode `json:"submodules,omitempty"`
+	CloneOptions *CloneOptions  `json:"cloneOptions,omitempty"`
+	PullOptions  *PullOptions   `json:"pullOptions,omitempty"`
+
 	Type    string    `json:"-"`
 	AbsPath string    `json:"-"`
 	State   RepoState `json:"-"`
@@ -33,6 +41,101 @@ var (
 	RepoStateOK      RepoState = "OK"
 )
 
+// RemoteOrigin is the remote name of the repository a clone was created from.
+const RemoteOrigin = "origin"
+
+// RemoteUpstream is the remote name of the repository a fork was created from.
+const RemoteUpstream = "upstream"
+
+// SubmodulesMode represents how repository submodules are handled.
+type SubmodulesMode string
+
+var (
+	SubmodulesNone      SubmodulesMode = ""
+	SubmodulesRecursive SubmodulesMode = "recursive"
+)
+
+// CloneOptions limit the history, objects or branches of repository clones.
+type CloneOptions struct {
+	Depth        int    `json:"depth,omitempty"`
+	Filter       string `json:"filter,omitempty"`
+	SingleBranch bool   `json:"singleBranch,omitempty"`
+	Branch       string `json:"branch,omitempty"`
+	Reference    string `json:"reference,omitempty"`
+}
+
+// Merge returns a copy of options, overridden by values set in other.
+func (o *CloneOptions) Merge(other *CloneOptions) *CloneOptions {
+	if o == nil {
+		return other
+	}
+	merged := *o
+	if other == nil {
+		return &merged
+	}
+	if other.Depth > 0 {
+		merged.Depth = other.Depth
+	}
+	if other.Filter != "" {
+		merged.Filter = other.Filter
+	}
+	if other.SingleBranch {
+		merged.SingleBranch = true
+	}
+	if other.Branch != "" {
+		merged.Branch = other.Branch
+	}
+	if other.Reference != "" {
+		merged.Reference = other.Reference
+	}
+	return &merged
+}
+
+// PullStrategy represents how pulled changes are integrated.
+type PullStrategy string
+
+var (
+	PullFastForward PullStrategy = "ff-only"
+	PullRebase      PullStrategy = "rebase"
+	PullMerge       PullStrategy = "merge"
+)
+
+// PullOptions control how repositories are pulled.
+type PullOptions struct {
+	Strategy  PullStrategy `json:"strategy,omitempty"`
+	Autostash bool         `json:"autostash,omitempty"`
+}
+
+// Validate checks the pull strategy is known.
+func (o PullOptions) Validate() error {
+	switch o.Strategy {
+	case "", PullFastForward, PullRebase, PullMerge:
+		return nil
+	}
+	return fmt.Errorf(
+		"unknown pull strategy %q, must be one of: %s, %s, %s",
+		o.Strategy, PullFastForward, PullRebase, PullMerge,
+	)
+}
+
+// Merge returns a copy of options, overridden by values set in other.
+func (o *PullOptions) Merge(other *PullOptions) *PullOptions {
+	if o == nil {
+		return other
+	}
+	merged := *o
+	if other == nil {
+		return &merged
+	}
+	if other.Strategy != "" {
+		merged.Strategy = other.Strategy
+	}
+	if other.Autostash {
+		merged.Autostash = true
+	}
+	return &merged
+}
+
 func (r Repository) GetName() string {
 	title := ""
 	switch {
@@ -1,12 +1,26 @@
 package domain
 
+import "time"
+
 type Settings struct {
-	Cache       *bool  `json:"cache,omitempty"`
-	Finder      Finder `json:"finder"`
-	Icons       Icons  `json:"icons"`
-	Theme       Theme  `json:"theme"`
-	Verbose     bool   `json:"verbose,omitempty"`
-	WorkerCount int    `json:"workerCount,omitempty"`
+	Cache       *bool    `json:"cache,omitempty"`
+	Finder      Finder   `json:"finder"`
+	Icons       Icons    `json:"icons"`
+	Theme       Theme    `json:"theme"`
+	Timeouts    Timeouts `json:"timeouts"`
+	Verbose     bool     `json:"verbose,omitempty"`
+	WorkerCount int      `json:"workerCount,omitempty"`
+}
+
+// Timeouts are per-operation git timeouts, e.g. "30s" or "5m". Unset
+// timeouts use defaults, and a negative timeout, e.g. "-1s", disables it.
+type Timeouts struct {
+	Clone  time.Duration `json:"clone,omitempty"`
+	Commit time.Duration `json:"commit,omitempty"`
+	Fetch  time.Duration `json:"fetch,omitempty"`
+	Pull   time.Duration `json:"pull,omitempty"`
+	Push   time.Duration `json:"push,omitempty"`
+	Status time.Duration `json:"status,omitempty"`
 }
 
 type Finder struct {
@@ -16,13 +30,19 @@ type Finder struct {
 }
 
 type Icons struct {
-	Modified  string `json:"modified,omitempty"`
-	Untracked string `json:"untracked,omitempty"`
-	DiffError string `json:"diffError,omitempty"`
-	DiffClean string `json:"diffClean,omitempty"`
-	Ahead     string `json:"ahead,omitempty"`
-	Behind    string `json:"behind,omitempty"`
-	NA        string `json:"na,omitempty"`
+	Staged     string `json:"staged,omitempty"`
+	Modified   string `json:"modified,omitempty"`
+	Untracked  string `json:"untracked,omitempty"`
+	Conflicted string `json:"conflicted,omitempty"`
+	Stashed    string `json:"stashed,omitempty"`
+	Detached   string `json:"detached,omitempty"`
+	InProgress string `json:"inProgress,omitempty"`
+	Submodules string `json:"submodules,omitempty"`
+	DiffError  string `json:"diffError,omitempty"`
+	DiffClean  string `json:"diffClean,omitempty"`
+	Ahead      string `json:"ahead,omitempty"`
+	Behind     string `json:"behind,omitempty"`
+	NA         string `json:"na,omitempty"`
 }
 
 type Style struct {
@@ -57,10 +77,16 @@ type Theme struct {
 	TagIndicator Style `json:"tagIndicator,omitempty"`
 
 	// Status
-	Modified  Style `json:"modified,omitempty"`
-	Untracked Style `json:"untracked,omitempty"`
-	Diff      Style `json:"diff,omitempty"`
-	Error     Style `json:"error,omitempty"`
+	Staged     Style `json:"staged,omitempty"`
+	Modified   Style `json:"modified,omitempty"`
+	Untracked  Style `json:"untracked,omitempty"`
+	Conflicted Style `json:"conflicted,omitempty"`
+	Stashed    Style `json:"stashed,omitempty"`
+	Detached   Style `json:"detached,omitempty"`
+	InProgress Style `json:"inProgress,omitempty"`
+	Submodules Style `json:"submodules,omitempty"`
+	Diff       Style `json:"diff,omitempty"`
+	Error      Style `json:"error,omitempty"`
 
 	// Table
 	TableBorderStyle Style `json:"tableBorderStyle,omitempty"`
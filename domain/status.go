@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// RepoStatus represents a snapshot of a repository's working tree state.
+type RepoStatus struct {
+	Branch     string    `json:"branch"`
+	Upstream   string    `json:"upstream,omitempty"`
+	Detached   bool      `json:"detached,omitempty"`
+	Ahead      int       `json:"ahead"`
+	Behind     int       `json:"behind"`
+	DiffFailed bool      `json:"diffFailed,omitempty"`
+	Staged     int       `json:"staged"`
+	Modified   int       `json:"modified"`
+	Untracked  int       `json:"untracked"`
+	Conflicted int       `json:"conflicted"`
+	Stashes    int       `json:"stashes"`
+	Operation  string    `json:"operation,omitempty"`
+	Submodules int       `json:"submodules"`
+	Version    string    `json:"version,omitempty"`
+	Position   string    `json:"position,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
@@ -16,6 +16,10 @@ type Cacher interface {
 	Get(key string, project *domain.Project) (bool, error)
 	Save(key string, project domain.Project) error
 	Flush(project domain.Project) error
+
+	// Repository status snapshots, keyed by repository absolute path.
+	GetStatus(repoPath string, status *domain.RepoStatus) (bool, error)
+	SaveStatus(repoPath string, status domain.RepoStatus) error
 }
 
 func NewCacheClient(name string) (Cacher, error) {
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/fileutil"
+	"github.com/rafi/gits/internal/version"
+)
+
+const statusCacheDir = "status"
+
+// StatusFile is a cached status snapshot of a single repository.
+type StatusFile struct {
+	Version string            `json:"version"`
+	Path    string            `json:"path"`
+	Status  domain.RepoStatus `json:"status"`
+}
+
+// statusFilePath returns the snapshot file path of a repository path.
+func statusFilePath(repoPath string) (string, error) {
+	hash := md5.Sum([]byte(repoPath))
+	key := filepath.Join(statusCacheDir, hex.EncodeToString(hash[:]))
+	return cacheFilePath(key)
+}
+
+// GetStatus reads the last saved status snapshot of a repository path.
+func (cf *File) GetStatus(repoPath string, status *domain.RepoStatus) (bool, error) {
+	path, err := statusFilePath(repoPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to get status file path: %w", err)
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Debugf("status snapshot not found for %s", repoPath)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read status file: %w", err)
+	}
+
+	snapshot := StatusFile{}
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return false, fmt.Errorf("failed to parse status file: %w", err)
+	}
+
+	// Bust snapshot if version or path mismatch
+	if snapshot.Version != version.GetMajorMinor() {
+		log.Debugf("status snapshot version mismatch for %s", repoPath)
+		return false, nil
+	}
+	if snapshot.Path != repoPath {
+		log.Debugf("status snapshot path mismatch for %s", repoPath)
+		return false, nil
+	}
+	*status = snapshot.Status
+	return true, nil
+}
+
+// SaveStatus persists a status snapshot of a repository path.
+func (cf *File) SaveStatus(repoPath string, status domain.RepoStatus) error {
+	path, err := statusFilePath(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get status file path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+
+	raw, err := json.Marshal(StatusFile{
+		Version: version.GetMajorMinor(),
+		Path:    repoPath,
+		Status:  status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode status file: %w", err)
+	}
+
+	if err := fileutil.WriteFileAtomic(path, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write status file: %w", err)
+	}
+	return nil
+}
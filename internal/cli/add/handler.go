@@ -11,6 +11,7 @@ import (
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
 )
 
 // ExecAdd adds the current repository to a project in the config file.
@@ -43,7 +44,9 @@ func ExecAdd(args []string, deps types.RuntimeCLI) error {
 		return err
 	}
 
-	remoteURL, err := deps.Git.Remote(cwd)
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	remoteURL, err := deps.Git.Remote(ctx, cwd)
 	if err != nil {
 		return fmt.Errorf("failed adding repo: %w", err)
 	}
@@ -78,7 +81,9 @@ func ensureRepository(args []string, deps types.RuntimeCLI) (string, error) {
 		remoteURL := args[1]
 		baseName := strings.TrimSuffix(filepath.Base(remoteURL), ".git")
 		cwd = filepath.Join(cwd, baseName)
-		output, err := deps.Git.Clone(remoteURL, cwd)
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+		defer cancel()
+		output, err := deps.Git.Clone(ctx, remoteURL, cwd, git.CloneOptions{})
 		if err != nil {
 			fmt.Println(output)
 			return "", err
@@ -0,0 +1,216 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/cli/exec"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+var resultHeaders = []string{"REPO", "COMMIT", "RESULT"}
+
+// Options are the apply command options.
+type Options struct {
+	// Script is run by the shell in each repository, instead of a patch.
+	Script string
+	// Branch is created from the default branch, and receives the change.
+	Branch string
+	// Message of the commit, defaults to the patch or script.
+	Message string
+}
+
+// ExecApply applies a patch, or runs a script, in project repositories on a
+// new branch, and commits the changes.
+//
+// Args:
+//   - patch file (unless a script is provided)
+//   - project name (optional)
+func ExecApply(opts Options, args []string, deps types.RuntimeCLI) error {
+	if opts.Branch == "" {
+		return errors.New("missing branch name")
+	}
+	patch := ""
+	if opts.Script == "" {
+		if len(args) == 0 || args[0] == "" {
+			return errors.New("missing patch file or script")
+		}
+		var err error
+		patch, err = filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to find patch: %w", err)
+		}
+		if _, err := os.Stat(patch); err != nil {
+			return fmt.Errorf("unable to read patch: %w", err)
+		}
+		args = args[1:]
+	}
+	if opts.Message == "" {
+		opts.Message = "Apply " + filepath.Base(patch)
+		if opts.Script != "" {
+			opts.Message = "Run " + opts.Script
+		}
+	}
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+	deps.Git = deps.Git.Batch()
+
+	results := cli.RepoResults(project)
+	cli.ForEach(len(results), deps.Settings.WorkerCount, func(idx int) {
+		applyRepo(&results[idx], patch, opts, deps)
+	})
+
+	table, errList := cli.ResultTable(resultHeaders, results, deps.Theme)
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	fmt.Println(table)
+
+	if len(errList) > 0 {
+		return cli.RenderErrors(errList, true)
+	}
+	return nil
+}
+
+// applyRepo creates the branch from the fetched origin default branch,
+// applies the change and commits it. Untouched repositories are restored to
+// their branch, and the created branch is deleted.
+func applyRepo(res *cli.RepoResult, patch string, opts Options, deps types.RuntimeCLI) {
+	res.Details = []string{""}
+	if !res.CheckState() {
+		return
+	}
+	repo := res.Repo
+
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	status, err := deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	res.Skipped = true
+	switch {
+	case status.Operation != git.OperationNone:
+		res.Status = fmt.Sprintf("%s in progress", status.Operation)
+		return
+	case status.Detached:
+		res.Status = "detached HEAD"
+		return
+	case status.Staged+status.Unstaged+status.Untracked+status.Conflicted > 0:
+		res.Status = "uncommitted changes"
+		return
+	}
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	if gitRepo.IsLocalBranch(ctx, opts.Branch) {
+		res.Status = "branch " + opts.Branch + " exists"
+		return
+	}
+	res.Skipped = false
+	original := status.Branch
+
+	// Fetch first, so the branch starts from up-to-date code.
+	fetchCtx, cancelFetch := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancelFetch()
+	if _, err := deps.Git.Fetch(fetchCtx, repo.AbsPath); err != nil {
+		res.Error = err
+		return
+	}
+
+	ctx, cancel = cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	base, err := deps.Git.DefaultBranch(ctx, repo.AbsPath, domain.RemoteOrigin)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	base = domain.RemoteOrigin + "/" + base
+	if _, err := deps.Git.CreateBranch(ctx, repo.AbsPath, opts.Branch, base); err != nil {
+		res.Error = err
+		return
+	}
+	if err := gitRepo.Checkout(ctx, opts.Branch); err != nil {
+		res.Error = fmt.Errorf("unable to checkout %s: %w", opts.Branch, err)
+		return
+	}
+
+	// Apply the change, without a timeout for long-running scripts.
+	var changeErr error
+	if opts.Script != "" {
+		changeErr = runScript(deps.Context, repo.AbsPath, opts.Script)
+	} else {
+		_, changeErr = deps.Git.ApplyPatch(ctx, repo.AbsPath, patch)
+	}
+
+	// Fresh timeouts, the change may have taken long.
+	ctx, cancel = cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	status, err = deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	changes := status.Staged + status.Unstaged + status.Untracked + status.Conflicted
+	switch {
+	case status.Conflicted > 0:
+		res.Status = fmt.Sprintf("conflicted, %d files", status.Conflicted)
+		res.Error = fmt.Errorf("resolve conflicts and commit on %s", opts.Branch)
+		return
+	case changes == 0:
+		// Restore the original branch, and remove the created branch.
+		if err := gitRepo.Checkout(ctx, original); err != nil {
+			res.Error = err
+			return
+		}
+		if _, err := deps.Git.DeleteBranch(ctx, repo.AbsPath, opts.Branch, true); err != nil {
+			res.Error = err
+			return
+		}
+		res.Status = "untouched"
+		if changeErr != nil {
+			res.Status, res.Error = "", changeErr
+		}
+		return
+	case changeErr != nil:
+		res.Error = changeErr
+		return
+	}
+
+	commitCtx, cancelCommit := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Commit)
+	defer cancelCommit()
+	commit, err := deps.Git.CommitAll(commitCtx, repo.AbsPath, opts.Message)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	res.Details = []string{cli.ShortHash(commit)}
+	res.Status = fmt.Sprintf("changed, %d files", changes)
+}
+
+// runScript runs a script with the shell in the repository directory, in its
+// own process group, so cancellation also kills the processes it spawns.
+func runScript(ctx context.Context, dir, script string) error {
+	cmd := exec.Command(ctx, []string{script})
+	cmd.Dir = dir
+	exec.SetProcessGroup(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		output := strings.TrimSpace(string(output))
+		if output != "" {
+			return fmt.Errorf("script failed: %w: %s", err, output)
+		}
+		return fmt.Errorf("script failed: %w", err)
+	}
+	return nil
+}
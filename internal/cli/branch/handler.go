@@ -0,0 +1,191 @@
+package branch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+)
+
+var resultHeaders = []string{"REPO", "BRANCH", "RESULT"}
+
+// Options are the branch command options.
+type Options struct {
+	// Base is the start point of created branches, defaults to each
+	// repository default branch on origin, after fetching.
+	Base string
+	// Force deletes branches even if they are not fully merged.
+	Force bool
+}
+
+// ExecCreate creates a branch in project repositories, without checking it
+// out. Branches start from the fetched origin default branch, unless a base
+// is given.
+//
+// Args:
+//   - branch name
+//   - project name (optional)
+//   - repo names (optional, defaults to all)
+func ExecCreate(opts Options, args []string, deps types.RuntimeCLI) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing branch name")
+	}
+	name := args[0]
+	return eachRepo(args[1:], deps, func(ctx context.Context, res *cli.RepoResult) {
+		res.Details = []string{name}
+		gitRepo, err := deps.Git.Open(res.Repo.AbsPath)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		if gitRepo.IsLocalBranch(ctx, name) {
+			res.Skipped, res.Status = true, "already exists"
+			return
+		}
+		base := opts.Base
+		if base == "" {
+			// Fetch first, so all repositories branch from up-to-date code.
+			fetchCtx, cancelFetch := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+			defer cancelFetch()
+			if _, err := deps.Git.Fetch(fetchCtx, res.Repo.AbsPath); err != nil {
+				res.Error = err
+				return
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+			defer cancel()
+			defaultBranch, err := deps.Git.DefaultBranch(ctx, res.Repo.AbsPath, domain.RemoteOrigin)
+			if err != nil {
+				res.Error = err
+				return
+			}
+			base = domain.RemoteOrigin + "/" + defaultBranch
+		}
+		if _, err := deps.Git.CreateBranch(ctx, res.Repo.AbsPath, name, base); err != nil {
+			res.Error = err
+			return
+		}
+		res.Status = "created from " + base
+	})
+}
+
+// ExecDelete deletes a branch in project repositories, refusing branches
+// that are not fully merged, unless forced.
+//
+// Args:
+//   - branch name
+//   - project name (optional)
+//   - repo names (optional, defaults to all)
+func ExecDelete(opts Options, args []string, deps types.RuntimeCLI) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing branch name")
+	}
+	name := args[0]
+	return eachRepo(args[1:], deps, func(ctx context.Context, res *cli.RepoResult) {
+		res.Details = []string{name}
+		gitRepo, err := deps.Git.Open(res.Repo.AbsPath)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		if !gitRepo.IsLocalBranch(ctx, name) {
+			res.Skipped, res.Status = true, "not found"
+			return
+		}
+		if _, err := deps.Git.DeleteBranch(ctx, res.Repo.AbsPath, name, opts.Force); err != nil {
+			res.Error = err
+			return
+		}
+		res.Status = "deleted"
+	})
+}
+
+// ExecRename renames a branch in project repositories.
+//
+// Args:
+//   - branch name
+//   - new branch name
+//   - project name (optional)
+//   - repo names (optional, defaults to all)
+func ExecRename(args []string, deps types.RuntimeCLI) error {
+	if len(args) < 2 || args[0] == "" || args[1] == "" {
+		return errors.New("missing branch name and new name")
+	}
+	name, newName := args[0], args[1]
+	return eachRepo(args[2:], deps, func(ctx context.Context, res *cli.RepoResult) {
+		res.Details = []string{name + " -> " + newName}
+		gitRepo, err := deps.Git.Open(res.Repo.AbsPath)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		if !gitRepo.IsLocalBranch(ctx, name) {
+			res.Skipped, res.Status = true, "not found"
+			return
+		}
+		if _, err := deps.Git.RenameBranch(ctx, res.Repo.AbsPath, name, newName); err != nil {
+			res.Error = err
+			return
+		}
+		res.Status = "renamed"
+	})
+}
+
+// eachRepo runs fn in cloned project repositories, optionally filtered by
+// names, and prints a result table.
+//
+// Args:
+//   - project name (optional)
+//   - repo names (optional, defaults to all)
+func eachRepo(
+	args []string,
+	deps types.RuntimeCLI,
+	fn func(context.Context, *cli.RepoResult),
+) error {
+	projectArgs := args
+	if len(projectArgs) > 1 {
+		projectArgs = projectArgs[:1]
+	}
+	project, _, err := cli.ParseArgs(projectArgs, true, deps)
+	if err != nil {
+		return err
+	}
+	filter := []string{}
+	if len(args) > 1 {
+		filter = args[1:]
+	}
+	deps.Git = deps.Git.Batch()
+
+	results := []cli.RepoResult{}
+	for _, res := range cli.RepoResults(project) {
+		if len(filter) == 0 || slices.Contains(filter, res.Name) || res.Repo.ContainedIn(filter) {
+			res.Details = []string{""}
+			results = append(results, res)
+		}
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no repositories matched in project %q", project.Name)
+	}
+	cli.ForEach(len(results), deps.Settings.WorkerCount, func(idx int) {
+		res := &results[idx]
+		if !res.CheckState() {
+			return
+		}
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+		defer cancel()
+		fn(ctx, res)
+	})
+
+	table, errList := cli.ResultTable(resultHeaders, results, deps.Theme)
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	fmt.Println(table)
+
+	if len(errList) > 0 {
+		return cli.RenderErrors(errList, true)
+	}
+	return nil
+}
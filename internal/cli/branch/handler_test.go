@@ -0,0 +1,101 @@
+package branch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git/gittest"
+)
+
+// newDeps returns runtime dependencies with a single project, "work", of
+// fake repositories by name.
+func newDeps(t *testing.T, repos map[string]*gittest.Repo) (types.RuntimeCLI, map[string]string) {
+	t.Helper()
+	root := t.TempDir()
+	paths := map[string]string{}
+	fakeRepos := map[string]*gittest.Repo{}
+	for name, repo := range repos {
+		path := filepath.Join(root, name)
+		if err := os.Mkdir(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		paths[name] = path
+		fakeRepos[path] = repo
+	}
+
+	deps := types.RuntimeCLI{}
+	deps.Context = context.Background()
+	deps.Git = gittest.New(fakeRepos)
+	deps.Projects = domain.ProjectListKeyed{"work": {Path: root}}
+	deps.Settings.WorkerCount = 2
+	return deps, paths
+}
+
+func newRepo(branches ...string) *gittest.Repo {
+	return &gittest.Repo{
+		Head:           "main",
+		Branches:       append([]string{"main"}, branches...),
+		RemoteBranches: []string{"origin/main"},
+		Remotes:        map[string]string{"origin": "https://example.com/repo.git"},
+		DefaultBranch:  "main",
+	}
+}
+
+func TestExecCreate(t *testing.T) {
+	deps, paths := newDeps(t, map[string]*gittest.Repo{
+		"api": newRepo(),
+		"web": newRepo("feature"),
+	})
+	fake := deps.Git.(*gittest.Fake)
+
+	if err := ExecCreate(Options{}, []string{"feature", "work"}, deps); err != nil {
+		t.Fatalf("ExecCreate() error = %v", err)
+	}
+	want := []string{"main", "feature"}
+	for name, path := range paths {
+		if got := fake.Repo(path).Branches; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s branches = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestExecDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+		want    []string
+	}{
+		{
+			name:    "unmerged",
+			wantErr: true,
+			want:    []string{"main", "feature"},
+		},
+		{
+			name: "forced",
+			opts: Options{Force: true},
+			want: []string{"main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo("feature")
+			repo.Unmerged = []string{"feature"}
+			deps, paths := newDeps(t, map[string]*gittest.Repo{"api": repo})
+			fake := deps.Git.(*gittest.Fake)
+
+			err := ExecDelete(tt.opts, []string{"feature", "work"}, deps)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := fake.Repo(paths["api"]).Branches; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("branches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
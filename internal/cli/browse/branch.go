@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/loader"
 	"github.com/rafi/gits/internal/types"
 	"github.com/rafi/gits/pkg/fzf"
@@ -32,6 +33,11 @@ var (
 //   - repo name
 //   - branch name (optional)
 func ExecBranchOverview(args []string, deps types.RuntimeCLI) error {
+	// Bound all git operations of the overview preview.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	deps.Context = ctx
+
 	// Project
 	if len(args) < 1 {
 		return fmt.Errorf("missing project name")
@@ -61,14 +67,14 @@ func ExecBranchOverview(args []string, deps types.RuntimeCLI) error {
 	if len(args) > 2 {
 		current = args[2]
 	} else {
-		current, err = repo.CurrentBranch()
+		current, err = repo.CurrentBranch(deps.Context)
 		if err != nil {
 			return fmt.Errorf("unable to get current branch: %w", err)
 		}
 	}
 
 	// Remote
-	remotes, err := repo.Remotes()
+	remotes, err := repo.Remotes(deps.Context)
 	if err != nil {
 		return fmt.Errorf("unable to get remotes: %w", err)
 	}
@@ -123,7 +129,7 @@ func ExecBranchOverview(args []string, deps types.RuntimeCLI) error {
 	panelLeft = "\n" + branchCurrentStyle.Render(current) + "\n\n" + panelLeft
 
 	// Render commits per day panelRight.
-	panelRight, err := renderBranchChart(deps.Git, foundRepo, current, chartWidth)
+	panelRight, err := renderBranchChart(deps, foundRepo, current, chartWidth)
 	if err != nil {
 		log.Warnf("unable to render chart: %s", err)
 	}
@@ -151,7 +157,7 @@ func ExecBranchOverview(args []string, deps types.RuntimeCLI) error {
 	docStyle := lipgloss.NewStyle().Padding(0)
 	fmt.Println(docStyle.Render(doc.String()))
 
-	log, err := deps.Git.Log(foundRepo.AbsPath, current)
+	log, err := deps.Git.Log(deps.Context, foundRepo.AbsPath, current)
 	if err != nil {
 		return err
 	}
@@ -166,7 +172,7 @@ func renderBranchDiffList(repo git.Repository, repoPath, subjectBranch string, r
 		b := append([]string{subjectBranch}, commonReleaseBranches...)
 		for _, branchName := range b {
 			target := fmt.Sprintf("%s/%s", remote, branchName)
-			if repo.IsRemoteBranch(remote, branchName) {
+			if repo.IsRemoteBranch(deps.Context, remote, branchName) {
 				branches[target] = remote
 				continue
 			}
@@ -175,7 +181,7 @@ func renderBranchDiffList(repo git.Repository, repoPath, subjectBranch string, r
 	theme := deps.Theme
 
 	for fullName, remoteName := range branches {
-		ahead, behind, err := deps.Git.Diff(repoPath, subjectBranch, fullName)
+		ahead, behind, err := deps.Git.Diff(deps.Context, repoPath, subjectBranch, fullName)
 		if err != nil {
 			return "", err
 		}
@@ -206,8 +212,8 @@ func renderBranchDiffList(repo git.Repository, repoPath, subjectBranch string, r
 }
 
 // renderBranchChart draws a chart of commits per day.
-func renderBranchChart(gitClient git.Git, repo domain.Repository, branch string, width int) (string, error) {
-	commits, err := gitClient.CommitDates(repo.AbsPath, branch, daysAgo)
+func renderBranchChart(deps types.RuntimeCLI, repo domain.Repository, branch string, width int) (string, error) {
+	commits, err := deps.Git.CommitDates(deps.Context, repo.AbsPath, branch, daysAgo)
 	if err != nil {
 		return "", fmt.Errorf("unable to get commit dates: %w", err)
 	}
@@ -0,0 +1,179 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+)
+
+// Options are the bundle and unbundle command options.
+type Options struct {
+	// Dir is the bundles directory, bundles are kept in a sub-directory per
+	// project.
+	Dir string
+	// Refs to bundle, defaults to all refs.
+	Refs []string
+}
+
+// ExecBundle creates a git bundle per project repository, and an index file
+// for unbundling them later.
+//
+// Args: (optional)
+//   - project name
+func ExecBundle(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+	dir, err := projectDir(opts.Dir, project)
+	if err != nil {
+		return err
+	}
+
+	entries, errs := bundleProjectRepos(project, "", dir, opts.Refs, deps)
+	index := Index{
+		Project:   project.Name,
+		CreatedAt: time.Now(),
+		Repos:     entries,
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	if err := index.Save(dir); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type Response struct {
+	entry      *IndexEntry
+	output     string
+	title      lipgloss.Style
+	error      error
+	errorStyle lipgloss.Style
+}
+
+func (r Response) String() string {
+	if r.error != nil {
+		return fmt.Sprintf("%s %s", r.title, r.errorStyle.Render(r.error.Error()))
+	}
+
+	return fmt.Sprintf(
+		"%s %s",
+		r.title.Render(),
+		r.output,
+	)
+}
+
+// projectDir returns the bundles directory of a project.
+func projectDir(dir string, project domain.Project) (string, error) {
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return "", fmt.Errorf("unable to expand path: %w", err)
+	}
+	return filepath.Join(dir, project.Name), nil
+}
+
+func bundleProjectRepos(
+	project domain.Project,
+	prefix string,
+	dir string,
+	refs []string,
+	deps types.RuntimeCLI,
+) ([]IndexEntry, []error) {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		entries = make([]IndexEntry, 0, len(project.Repos))
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, repo := range project.Repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := bundleRepo(project, repo, prefix, dir, refs, deps)
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			mu.Lock()
+			if resp.entry != nil {
+				entries = append(entries, *resp.entry)
+			}
+			if resp.error != nil {
+				errList = append(errList, resp.error)
+			}
+			mu.Unlock()
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		subPrefix := prefix + subProject.Name + "/"
+		subEntries, errs := bundleProjectRepos(subProject, subPrefix, dir, refs, deps)
+		entries = append(entries, subEntries...)
+		errList = append(errList, errs...)
+	}
+	return entries, errList
+}
+
+func bundleRepo(
+	project domain.Project,
+	repo domain.Repository,
+	prefix string,
+	dir string,
+	refs []string,
+	deps types.RuntimeCLI,
+) Response {
+	resp := Response{
+		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+
+	name := prefix + repo.GetName()
+	file := filepath.Join(dir, name+".bundle")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	// Bundling packs the whole history, like a clone.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+	defer cancel()
+	_, err := deps.Git.Bundle(ctx, repo.AbsPath, file, refs)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.entry = &IndexEntry{
+		Name:   name,
+		Source: repo.Src,
+		Bundle: name + ".bundle",
+		Refs:   refs,
+	}
+	resp.output = deps.Theme.GitOutput.Render(cli.Path(file, deps.HomeDir))
+	return resp
+}
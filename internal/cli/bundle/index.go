@@ -0,0 +1,61 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rafi/gits/internal/fileutil"
+)
+
+const indexFilename = "index.json"
+
+// Index lists the repository bundles of a project.
+type Index struct {
+	Project   string       `json:"project"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Repos     []IndexEntry `json:"repos"`
+}
+
+// IndexEntry is a single repository bundle.
+type IndexEntry struct {
+	// Name is the repository name, prefixed by its sub-project.
+	Name string `json:"name"`
+	// Source is the configured remote of the repository.
+	Source string `json:"source"`
+	// Bundle is the bundle file path, relative to the index.
+	Bundle string `json:"bundle"`
+	// Refs are the bundled refs, empty if all refs are bundled.
+	Refs []string `json:"refs,omitempty"`
+}
+
+// readIndex reads the bundle index of a directory.
+func readIndex(dir string) (Index, error) {
+	index := Index{}
+	content, err := os.ReadFile(filepath.Join(dir, indexFilename))
+	if err != nil {
+		return index, fmt.Errorf("failed to read bundle index: %w", err)
+	}
+	if err := json.Unmarshal(content, &index); err != nil {
+		return index, fmt.Errorf("failed to parse bundle index: %w", err)
+	}
+	return index, nil
+}
+
+// Save writes the index into the bundles directory.
+func (i Index) Save(dir string) error {
+	sort.Slice(i.Repos, func(a, b int) bool {
+		return i.Repos[a].Name < i.Repos[b].Name
+	})
+	raw, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle index: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(filepath.Join(dir, indexFilename), raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write bundle index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+// ExecUnbundle clones project repositories from bundles listed in an index
+// file, and resets their origin remote to the configured source.
+//
+// Args: (optional)
+//   - project name
+func ExecUnbundle(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+	dir, err := projectDir(opts.Dir, project)
+	if err != nil {
+		return err
+	}
+	index, err := readIndex(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, entry := range index.Repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := unbundleRepo(project, entry, dir, deps)
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			if resp.error != nil {
+				mu.Lock()
+				errList = append(errList, resp.error)
+				mu.Unlock()
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	if len(errList) > 0 {
+		return cli.RenderErrors(errList, true)
+	}
+	return nil
+}
+
+func unbundleRepo(project domain.Project, entry IndexEntry, dir string, deps types.RuntimeCLI) Response {
+	repo, found := project.GetRepo(entry.Name, "")
+	if !found {
+		repo = domain.Repository{Name: entry.Name}
+	}
+	resp := Response{
+		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+	switch {
+	case !found:
+		resp.error = cli.RepoError(errors.New("not found in project"), repo)
+		return resp
+	case repo.AbsPath == "":
+		resp.error = cli.RepoError(errors.New("no local path"), repo)
+		return resp
+	}
+	if _, err := os.Stat(repo.AbsPath); !os.IsNotExist(err) {
+		repoPath := cli.Path(repo.AbsPath, deps.HomeDir)
+		resp.error = types.NewWarning("already cloned at %s", repoPath)
+		return resp
+	}
+
+	// Bundles of selected refs have no HEAD, checkout the first one.
+	opts := git.CloneOptions{}
+	if len(entry.Refs) > 0 {
+		opts.Branch = strings.TrimPrefix(entry.Refs[0], "refs/heads/")
+		opts.Branch = strings.TrimPrefix(opts.Branch, "refs/tags/")
+	}
+
+	file := filepath.Join(dir, entry.Bundle)
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+	defer cancel()
+	_, err := deps.Git.Clone(ctx, file, repo.AbsPath, opts)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	src := repo.Src
+	if src == "" {
+		src = entry.Source
+	}
+	if src != "" {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+		defer cancel()
+		err := deps.Git.SetRemoteURL(ctx, repo.AbsPath, domain.RemoteOrigin, src)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+	}
+	resp.output = deps.Theme.GitOutput.Render(fmt.Sprintf("origin %s", src))
+	return resp
+}
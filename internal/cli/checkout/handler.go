@@ -79,7 +79,7 @@ func checkoutRepo(project domain.Project, repo domain.Repository, deps types.Run
 		return nil
 	}
 
-	err = gitRepo.Checkout(branch)
+	err = gitRepo.Checkout(deps.Context, branch)
 	if err != nil {
 		fmt.Print(deps.Theme.Error.Render(err.Error()))
 		return cli.RepoError(err, repo)
@@ -89,14 +89,14 @@ func checkoutRepo(project domain.Project, repo domain.Repository, deps types.Run
 
 // promptRepo prompts the user to select a branch to checkout.
 func promptRepo(repoTitle string, gitRepo git.Repository, deps types.RuntimeCLI) (string, error) {
-	current, err := gitRepo.CurrentBranch()
+	current, err := gitRepo.CurrentBranch(deps.Context)
 	if err != nil {
 		return "", fmt.Errorf("unable to get branch: %w", err)
 	}
 
 	ps := fmt.Sprintf("%s [%s]> ", repoTitle, current)
 
-	branches, err := gitRepo.Branches()
+	branches, err := gitRepo.Branches(deps.Context)
 	if err != nil {
 		log.Fatal(fmt.Sprintf("Unable to read branches: %s", err))
 	}
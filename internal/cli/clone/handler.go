@@ -2,23 +2,29 @@ package clone
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mitchellh/go-homedir"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
 )
 
-// ExecClone clones project repositories, or a specific repo.
+// ExecClone clones project repositories, or a specific repo. Clone options
+// override the ones configured for project and repositories.
 //
 // Args: (optional)
 //   - project name
 //   - repo or sub-project name
-func ExecClone(args []string, deps types.RuntimeCLI) error {
+func ExecClone(opts domain.CloneOptions, args []string, deps types.RuntimeCLI) error {
 	project, repo, err := cli.ParseArgs(args, true, deps)
 	if err != nil {
 		return err
@@ -26,13 +32,13 @@ func ExecClone(args []string, deps types.RuntimeCLI) error {
 
 	if repo != nil {
 		// Clone a single repository.
-		resp := cloneRepo(project, *repo, deps)
+		resp := cloneRepo(project, *repo, opts, deps)
 		fmt.Println(resp)
 		return err
 	}
 
 	// Clone all project's repositories.
-	errs := cloneProjectRepos(project, deps)
+	errs := cloneProjectRepos(project, opts, deps)
 	if len(errs) > 0 {
 		return cli.RenderErrors(errs, true)
 	}
@@ -58,8 +64,9 @@ func (r CloneResponse) String() string {
 	)
 }
 
-func cloneProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
+func cloneProjectRepos(project domain.Project, opts domain.CloneOptions, deps types.RuntimeCLI) []error {
 	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	deps.Git = deps.Git.Batch()
 
 	errList := make([]error, 0)
 	maxLen := cli.GetMaxLen(project)
@@ -74,7 +81,7 @@ func cloneProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			resp := cloneRepo(project, repo, deps)
+			resp := cloneRepo(project, repo, opts, deps)
 			resp.title.Width(maxLen)
 			fmt.Println(resp)
 			if resp.error != nil {
@@ -89,13 +96,18 @@ func cloneProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
 
 	for _, subProject := range project.SubProjects {
 		fmt.Println()
-		errs := cloneProjectRepos(subProject, deps)
+		errs := cloneProjectRepos(subProject, opts, deps)
 		errList = append(errList, errs...)
 	}
 	return errList
 }
 
-func cloneRepo(project domain.Project, repo domain.Repository, deps types.RuntimeCLI) CloneResponse {
+func cloneRepo(
+	project domain.Project,
+	repo domain.Repository,
+	opts domain.CloneOptions,
+	deps types.RuntimeCLI,
+) CloneResponse {
 	resp := CloneResponse{
 		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
 		errorStyle: deps.Theme.Error,
@@ -111,12 +123,49 @@ func cloneRepo(project domain.Project, repo domain.Repository, deps types.Runtim
 		return resp
 	}
 
-	var err error
-	resp.output, err = deps.Git.Clone(repo.Src, repo.AbsPath)
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+	defer cancel()
+
+	cloneOpts, err := gitCloneOptions(repo.CloneOptions.Merge(&opts))
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
 	}
+	resp.output, err = deps.Git.Clone(ctx, repo.Src, repo.AbsPath, cloneOpts)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	for _, name := range slices.Sorted(maps.Keys(repo.Remotes)) {
+		err := deps.Git.AddRemote(ctx, repo.AbsPath, name, repo.Remotes[name])
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+	}
+	if repo.Submodules == domain.SubmodulesRecursive {
+		output, err := deps.Git.UpdateSubmodules(ctx, repo.AbsPath)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		resp.output = strings.TrimSpace(resp.output + "\n" + output)
+	}
 	resp.output = deps.Theme.GitOutput.Render(resp.output)
 	return resp
 }
+
+// gitCloneOptions converts clone options, expanding the reference path.
+func gitCloneOptions(opts *domain.CloneOptions) (git.CloneOptions, error) {
+	reference, err := homedir.Expand(opts.Reference)
+	if err != nil {
+		return git.CloneOptions{}, fmt.Errorf("unable to expand reference path: %w", err)
+	}
+	return git.CloneOptions{
+		Depth:        opts.Depth,
+		Filter:       opts.Filter,
+		SingleBranch: opts.SingleBranch,
+		Branch:       opts.Branch,
+		Reference:    reference,
+	}, nil
+}
@@ -0,0 +1,276 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+var resultHeaders = []string{"REPO", "COMMIT", "RESULT"}
+
+// Options are the commit command options.
+type Options struct {
+	Message string
+	// All commits every dirty repository, without selecting them.
+	All bool
+	// Push pushes commits to upstream.
+	Push bool
+}
+
+// repoChanges are the uncommitted changes of a single repository, and the
+// commit result.
+type repoChanges struct {
+	name     string
+	repo     domain.Repository
+	upstream string
+	files    []git.FileStat
+	commit   string
+	output   string
+	error    error
+	// skipped is the reason a dirty repository can't be committed.
+	skipped string
+}
+
+// ExecCommit stages and commits all changes of dirty project repositories
+// with a shared message, after showing a combined diffstat.
+//
+// Args: (optional)
+//   - project name
+func ExecCommit(opts Options, args []string, deps types.RuntimeCLI) error {
+	if strings.TrimSpace(opts.Message) == "" {
+		return errors.New("missing commit message")
+	}
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+	deps.Git = deps.Git.Batch()
+
+	changes, skipped, errs := collectChanges(project, deps)
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	if len(changes) == 0 {
+		fmt.Println("  " + deps.Theme.Provider.Render("nothing to commit"))
+		if len(skipped) > 0 {
+			table, _ := cli.ResultTable(resultHeaders, skipped, deps.Theme)
+			fmt.Println(table)
+		}
+		if len(errs) > 0 {
+			return cli.RenderErrors(errs, true)
+		}
+		return nil
+	}
+	fmt.Println(renderDiffstat(changes, deps))
+
+	if !opts.All {
+		changes, err = selectRepos(project, changes, deps)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+
+	cli.ForEach(len(changes), deps.Settings.WorkerCount, func(idx int) {
+		commitRepo(&changes[idx], opts, deps)
+	})
+
+	results := make([]cli.RepoResult, 0, len(changes))
+	for _, c := range changes {
+		results = append(results, cli.RepoResult{
+			Name:    c.name,
+			Repo:    c.repo,
+			Details: []string{cli.ShortHash(c.commit)},
+			Status:  c.output,
+			Error:   c.error,
+		})
+	}
+	results = append(results, skipped...)
+	table, commitErrs := cli.ResultTable(resultHeaders, results, deps.Theme)
+	fmt.Println(table)
+	errs = append(errs, commitErrs...)
+
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// collectChanges returns the changes of dirty cloned repositories, ordered
+// by namespaced repository name. Repositories with conflicts, in-progress
+// operations or a detached HEAD are returned as skipped results.
+func collectChanges(
+	project domain.Project,
+	deps types.RuntimeCLI,
+) ([]repoChanges, []cli.RepoResult, []error) {
+	names := project.ListReposWithNamespace()
+	results := make([]repoChanges, len(names))
+	repoErrs := make([]error, len(names))
+	cli.ForEach(len(names), deps.Settings.WorkerCount, func(idx int) {
+		repo, found := project.GetRepo(names[idx], "")
+		if found && repo.State == domain.RepoStateOK {
+			results[idx], repoErrs[idx] = repoStatus(names[idx], repo, deps)
+		}
+	})
+
+	errList := slices.DeleteFunc(repoErrs, func(err error) bool { return err == nil })
+	changes := []repoChanges{}
+	skipped := []cli.RepoResult{}
+	for _, result := range results {
+		switch {
+		case result.skipped != "":
+			skipped = append(skipped, cli.RepoResult{
+				Name:    result.name,
+				Repo:    result.repo,
+				Details: []string{""},
+				Status:  result.skipped,
+				Skipped: true,
+			})
+		case len(result.files) > 0:
+			changes = append(changes, result)
+		}
+	}
+	return changes, skipped, errList
+}
+
+func repoStatus(name string, repo domain.Repository, deps types.RuntimeCLI) (repoChanges, error) {
+	changes := repoChanges{name: name, repo: repo}
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+
+	status, err := deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		return changes, cli.RepoError(err, repo)
+	}
+	if status.Staged+status.Unstaged+status.Untracked+status.Conflicted == 0 {
+		return changes, nil
+	}
+	switch {
+	case status.Operation != git.OperationNone:
+		changes.skipped = fmt.Sprintf("%s in progress", status.Operation)
+		return changes, nil
+	case status.Conflicted > 0:
+		changes.skipped = "unresolved conflicts"
+		return changes, nil
+	case status.Detached:
+		changes.skipped = "detached HEAD"
+		return changes, nil
+	}
+	changes.upstream = status.Upstream
+	changes.files, err = deps.Git.ChangedFiles(ctx, repo.AbsPath)
+	if err != nil {
+		return changes, cli.RepoError(err, repo)
+	}
+	return changes, nil
+}
+
+// renderDiffstat returns a combined diffstat of all repositories.
+func renderDiffstat(changes []repoChanges, deps types.RuntimeCLI) string {
+	maxLen := 0
+	for _, c := range changes {
+		for _, file := range c.files {
+			maxLen = max(maxLen, len(path.Join(c.name, file.Path)))
+		}
+	}
+
+	var b strings.Builder
+	files, added, deleted := 0, 0, 0
+	for _, c := range changes {
+		for _, file := range c.files {
+			files++
+			added += file.Added
+			deleted += file.Deleted
+			fmt.Fprintf(
+				&b,
+				"  %s%s | %s\n",
+				deps.Theme.RepoTitle.Render(c.name+"/"),
+				deps.Theme.RepoPath.Width(maxLen-len(c.name)-1).Render(file.Path),
+				fileStat(file),
+			)
+		}
+	}
+	fmt.Fprintf(
+		&b,
+		"  %s",
+		deps.Theme.Provider.Render(fmt.Sprintf(
+			"%d files changed in %d repositories, %d insertions(+), %d deletions(-)",
+			files, len(changes), added, deleted,
+		)),
+	)
+	return b.String()
+}
+
+func fileStat(file git.FileStat) string {
+	switch {
+	case file.Untracked:
+		return "new"
+	case file.Binary:
+		return "bin"
+	}
+	return fmt.Sprintf("+%d -%d", file.Added, file.Deleted)
+}
+
+// selectRepos selects repositories to commit interactively.
+func selectRepos(
+	project domain.Project,
+	changes []repoChanges,
+	deps types.RuntimeCLI,
+) ([]repoChanges, error) {
+	lines := make([]string, 0, len(changes))
+	for _, c := range changes {
+		added, deleted := 0, 0
+		for _, file := range c.files {
+			added += file.Added
+			deleted += file.Deleted
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%s %s",
+			deps.Theme.RepoTitle.Render(c.name),
+			deps.Theme.Provider.Render(fmt.Sprintf("(%d files, +%d -%d)", len(c.files), added, deleted)),
+		))
+	}
+	prompt := fmt.Sprintf("[%s] commit> ", project.Name)
+	indexes, err := cli.SelectIndexes(lines, prompt, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select repositories: %w", err)
+	}
+
+	result := make([]repoChanges, 0, len(indexes))
+	for _, idx := range indexes {
+		result = append(result, changes[idx])
+	}
+	return result, nil
+}
+
+// commitRepo stages and commits all changes, and pushes them if requested.
+func commitRepo(c *repoChanges, opts Options, deps types.RuntimeCLI) {
+	// Commit hooks may be slow, e.g. linters and tests.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Commit)
+	defer cancel()
+	c.commit, c.error = deps.Git.CommitAll(ctx, c.repo.AbsPath, opts.Message)
+	if c.error != nil {
+		return
+	}
+	c.output = "committed"
+	if !opts.Push {
+		return
+	}
+	if c.upstream == "" {
+		c.output += ", no upstream, not pushed"
+		return
+	}
+
+	ctx, cancel = cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Push)
+	defer cancel()
+	if _, err := deps.Git.Push(ctx, c.repo.AbsPath, git.PushOptions{}); err != nil {
+		c.output += ", push failed"
+		c.error = err
+		return
+	}
+	c.output += ", pushed to " + c.upstream
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/knadh/koanf/parsers/json"
@@ -19,6 +20,17 @@ import (
 	"github.com/rafi/gits/domain"
 )
 
+// Default per-operation git timeouts, used when unset. Negative timeouts
+// disable them.
+const (
+	defaultCloneTimeout  = 30 * time.Minute
+	defaultCommitTimeout = 10 * time.Minute
+	defaultFetchTimeout  = 5 * time.Minute
+	defaultPullTimeout   = 5 * time.Minute
+	defaultPushTimeout   = 5 * time.Minute
+	defaultStatusTimeout = 30 * time.Second
+)
+
 // File represents a config file with projects and settings.
 type File struct {
 	client   *koanf.Koanf
@@ -44,6 +56,7 @@ func NewConfigFromFile(filePath string, cfg *File) error {
 			return fmt.Errorf("unable to find config file: %w", err)
 		}
 		if filePath == "" {
+			cfg.setDefaults()
 			return nil
 		}
 	}
@@ -91,6 +104,31 @@ func (f *File) findDefaultPath() (string, error) {
 	return "", nil
 }
 
+// setDefaults sets unset settings to their defaults.
+func (f *File) setDefaults() {
+	if f.Settings.WorkerCount == 0 {
+		f.Settings.WorkerCount = max(runtime.NumCPU()/2, 2)
+	}
+	if f.Settings.Timeouts.Clone == 0 {
+		f.Settings.Timeouts.Clone = defaultCloneTimeout
+	}
+	if f.Settings.Timeouts.Commit == 0 {
+		f.Settings.Timeouts.Commit = defaultCommitTimeout
+	}
+	if f.Settings.Timeouts.Fetch == 0 {
+		f.Settings.Timeouts.Fetch = defaultFetchTimeout
+	}
+	if f.Settings.Timeouts.Pull == 0 {
+		f.Settings.Timeouts.Pull = defaultPullTimeout
+	}
+	if f.Settings.Timeouts.Push == 0 {
+		f.Settings.Timeouts.Push = defaultPushTimeout
+	}
+	if f.Settings.Timeouts.Status == 0 {
+		f.Settings.Timeouts.Status = defaultStatusTimeout
+	}
+}
+
 // loadConfig reads in config file and ENV variables if set.
 func (f *File) loadConfig(filePath string) error {
 	f.client = koanf.New(".")
@@ -130,9 +168,7 @@ func (f *File) loadConfig(filePath string) error {
 		return fmt.Errorf("unable to parse config file: %w", err)
 	}
 
-	if f.Settings.WorkerCount == 0 {
-		f.Settings.WorkerCount = max(runtime.NumCPU()/2, 2)
-	}
+	f.setDefaults()
 
 	// Set never/always color toggle.
 	switch f.Color {
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewConfigFromFileDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	tests := []struct {
+		name        string
+		content     string
+		wantStatus  time.Duration
+		wantFetch   time.Duration
+		wantWorkers int
+	}{
+		{
+			name:       "no config file",
+			wantStatus: defaultStatusTimeout,
+			wantFetch:  defaultFetchTimeout,
+		},
+		{
+			name:        "partial settings",
+			content:     "settings:\n  workerCount: 3\n  timeouts:\n    status: 5s\n    fetch: -1s\n",
+			wantStatus:  5 * time.Second,
+			wantFetch:   -time.Second,
+			wantWorkers: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.content != "" {
+				path = filepath.Join(dir, "gits.yaml")
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := File{}
+			if err := NewConfigFromFile(path, &cfg); err != nil {
+				t.Fatalf("NewConfigFromFile() error = %v", err)
+			}
+			timeouts := cfg.Settings.Timeouts
+			if timeouts.Status != tt.wantStatus || timeouts.Fetch != tt.wantFetch {
+				t.Errorf("timeouts = %+v, want status %s and fetch %s", timeouts, tt.wantStatus, tt.wantFetch)
+			}
+			if timeouts.Clone == 0 || timeouts.Commit == 0 || timeouts.Pull == 0 || timeouts.Push == 0 {
+				t.Errorf("timeouts = %+v, want defaults for unset timeouts", timeouts)
+			}
+			if tt.wantWorkers > 0 && cfg.Settings.WorkerCount != tt.wantWorkers {
+				t.Errorf("WorkerCount = %d, want %d", cfg.Settings.WorkerCount, tt.wantWorkers)
+			}
+			if cfg.Settings.WorkerCount < 1 {
+				t.Errorf("WorkerCount = %d, want a default", cfg.Settings.WorkerCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/rafi/gits/domain"
+)
+
+// NewIconsDefault returns the default status icons.
+func NewIconsDefault() domain.Icons {
+	return domain.Icons{
+		Staged:     "+",
+		Modified:   "≠",
+		Untracked:  "?",
+		Conflicted: "✘",
+		Stashed:    "⚑",
+		Detached:   "⌀",
+		InProgress: "⟳",
+		Submodules: "◫",
+		DiffError:  "-",
+		DiffClean:  "✓",
+		Ahead:      "▲",
+		Behind:     "▼",
+		NA:         "N/A",
+	}
+}
+
+// ParseIcons returns the configured icons, with defaults for missing ones.
+func ParseIcons(cfg domain.Icons) domain.Icons {
+	icons := NewIconsDefault()
+	v := reflect.ValueOf(cfg)
+	vp := reflect.ValueOf(&icons).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).String() != "" {
+			vp.Field(i).Set(v.Field(i))
+		}
+	}
+	return icons
+}
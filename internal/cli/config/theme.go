@@ -35,10 +35,16 @@ type Theme struct {
 	TagIndicator lipgloss.Style
 
 	// Status
-	Modified  lipgloss.Style
-	Untracked lipgloss.Style
-	Diff      lipgloss.Style
-	Error     lipgloss.Style
+	Staged     lipgloss.Style
+	Modified   lipgloss.Style
+	Untracked  lipgloss.Style
+	Conflicted lipgloss.Style
+	Stashed    lipgloss.Style
+	Detached   lipgloss.Style
+	InProgress lipgloss.Style
+	Submodules lipgloss.Style
+	Diff       lipgloss.Style
+	Error      lipgloss.Style
 
 	// List table
 	TableBorder      lipgloss.Border
@@ -122,10 +128,16 @@ func NewThemeDefault() Theme {
 		TagIndicator: lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
 
 		// Status
-		Modified:  lipgloss.NewStyle().Foreground(lipgloss.Color("169")).Width(3).Align(lipgloss.Right),
-		Untracked: lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Width(3).Align(lipgloss.Right),
-		Diff:      lipgloss.NewStyle().Foreground(lipgloss.Color("140")).Align(lipgloss.Right),
-		Error:     lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		Staged:     lipgloss.NewStyle().Foreground(lipgloss.Color("114")).Width(3).Align(lipgloss.Right),
+		Modified:   lipgloss.NewStyle().Foreground(lipgloss.Color("169")).Width(3).Align(lipgloss.Right),
+		Untracked:  lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Width(3).Align(lipgloss.Right),
+		Conflicted: lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+		Stashed:    lipgloss.NewStyle().Foreground(lipgloss.Color("179")),
+		Detached:   lipgloss.NewStyle().Foreground(lipgloss.Color("208")),
+		InProgress: lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true),
+		Submodules: lipgloss.NewStyle().Foreground(lipgloss.Color("179")),
+		Diff:       lipgloss.NewStyle().Foreground(lipgloss.Color("140")).Align(lipgloss.Right),
+		Error:      lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
 
 		// List table
 		TableBorder:      lipgloss.NormalBorder(),
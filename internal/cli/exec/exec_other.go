@@ -0,0 +1,8 @@
+//go:build !unix
+
+package exec
+
+import osexec "os/exec"
+
+// SetProcessGroup is a no-op where process groups are not supported.
+func SetProcessGroup(_ *osexec.Cmd) {}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package exec
+
+import (
+	osexec "os/exec"
+	"syscall"
+)
+
+// SetProcessGroup runs the command in its own process group, so that
+// cancellation also kills the processes it spawns. The command is then in
+// the background, it must not read from the terminal.
+func SetProcessGroup(cmd *osexec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
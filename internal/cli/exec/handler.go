@@ -0,0 +1,250 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+)
+
+// Options are the exec command options.
+type Options struct {
+	// Command and its arguments. A single argument is run by the shell.
+	Command []string
+	// Serial runs one repository at a time, streaming its output.
+	Serial bool
+	// OnlyDirty skips repositories without local changes.
+	OnlyDirty bool
+	// OnlyCloned skips repositories that are not cloned.
+	OnlyCloned bool
+	// FailFast stops running the command after the first failure.
+	FailFast bool
+}
+
+// ExecCommand runs a command in project repositories, or a specific repo,
+// and summarizes failures.
+//
+// Args: (optional)
+//   - project name
+//   - repo
+func ExecCommand(opts Options, args []string, deps types.RuntimeCLI) error {
+	if len(opts.Command) == 0 {
+		return errors.New("missing command, provide it after '--'")
+	}
+	project, repo, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(deps.Context)
+	defer cancel()
+	deps.Context = ctx
+
+	if repo != nil {
+		// Run in a single repository.
+		opts.Serial = true
+		resp := execRepo(project, *repo, opts, deps)
+		if !resp.skipped {
+			fmt.Println(resp)
+		}
+		if resp.error != nil {
+			// Repository errors are warnings, fail with a real error.
+			return cli.RenderErrors([]error{resp.error}, true)
+		}
+		return nil
+	}
+
+	// Run in all project's repositories.
+	errs := execProjectRepos(project, opts, cancel, deps)
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type ExecResponse struct {
+	duration    time.Duration
+	output      string
+	skipped     bool
+	streamed    bool
+	title       lipgloss.Style
+	error       error
+	errorStyle  lipgloss.Style
+	resultStyle lipgloss.Style
+}
+
+func (r ExecResponse) String() string {
+	result := r.resultStyle.Render(fmt.Sprintf("(%s)", r.duration.Round(time.Millisecond)))
+	if r.error != nil {
+		result = r.errorStyle.Render(r.error.Error())
+	}
+	switch {
+	case r.streamed && r.error == nil:
+		// Title and output were already rendered.
+		return ""
+	case r.streamed:
+		return result + "\n"
+	case r.output == "":
+		return fmt.Sprintf("%s %s", r.title.Render(), result)
+	}
+	return fmt.Sprintf("%s %s\n%s\n", r.title.Render(), result, r.output)
+}
+
+func execProjectRepos(
+	project domain.Project,
+	opts Options,
+	cancel context.CancelFunc,
+	deps types.RuntimeCLI,
+) []error {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, repo := range project.Repos {
+		if deps.Context.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		run := func() {
+			defer wg.Done()
+			resp := execRepo(project, repo, opts, deps)
+			if resp.skipped {
+				return
+			}
+			resp.title = resp.title.Width(maxLen)
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Println(resp)
+			if resp.error != nil {
+				errList = append(errList, resp.error)
+				if opts.FailFast {
+					cancel()
+				}
+			}
+		}
+		if opts.Serial {
+			run()
+			continue
+		}
+		go run()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		if deps.Context.Err() != nil {
+			break
+		}
+		fmt.Println()
+		errs := execProjectRepos(subProject, opts, cancel, deps)
+		errList = append(errList, errs...)
+	}
+	return errList
+}
+
+func execRepo(
+	project domain.Project,
+	repo domain.Repository,
+	opts Options,
+	deps types.RuntimeCLI,
+) ExecResponse {
+	resp := ExecResponse{
+		title:       cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle:  deps.Theme.Error,
+		resultStyle: deps.Theme.Provider,
+	}
+
+	// Skip, or abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		if opts.OnlyCloned || opts.OnlyDirty {
+			resp.skipped = true
+			return resp
+		}
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+	if opts.OnlyDirty {
+		dirty, err := isDirty(repo, deps)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		resp.skipped = !dirty
+		if resp.skipped {
+			return resp
+		}
+	}
+
+	cmd := Command(deps.Context, opts.Command)
+	cmd.Dir = repo.AbsPath
+
+	// Stream output when running serially, otherwise group it per repository.
+	// Serial commands stay in the terminal's foreground process group, so
+	// they can read from it and receive its signals.
+	var output bytes.Buffer
+	if opts.Serial {
+		resp.streamed = true
+		fmt.Println(resp.title.Render())
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	} else {
+		SetProcessGroup(cmd)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	resp.duration = time.Since(start)
+	resp.output = strings.TrimRight(output.String(), "\n")
+	if ctxErr := deps.Context.Err(); err != nil && ctxErr != nil {
+		err = ctxErr
+	}
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+	}
+	return resp
+}
+
+// Command returns the command to run, a single argument is run by the shell
+// to allow pipes and expansions.
+func Command(ctx context.Context, args []string) *osexec.Cmd {
+	if len(args) == 1 {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "sh"
+		}
+		return osexec.CommandContext(ctx, shell, "-c", args[0])
+	}
+	return osexec.CommandContext(ctx, args[0], args[1:]...)
+}
+
+// isDirty returns true if repository has local changes.
+func isDirty(repo domain.Repository, deps types.RuntimeCLI) (bool, error) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	status, err := deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		return false, err
+	}
+	changes := status.Staged + status.Unstaged + status.Untracked + status.Conflicted
+	return changes > 0, nil
+}
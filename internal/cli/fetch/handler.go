@@ -58,6 +58,7 @@ func (r FetchResponse) String() string {
 
 func fetchProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
 	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	deps.Git = deps.Git.Batch()
 
 	errList := make([]error, 0)
 	maxLen := cli.GetMaxLen(project)
@@ -101,8 +102,11 @@ func fetchRepo(project domain.Project, repo domain.Repository, deps types.Runtim
 		return resp
 	}
 
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancel()
+
 	var err error
-	resp.output, err = deps.Git.Fetch(repo.AbsPath)
+	resp.output, err = deps.Git.Fetch(ctx, repo.AbsPath)
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/rafi/gits/domain"
@@ -185,7 +187,9 @@ func SelectBranch(
 	repo domain.Repository,
 	deps types.RuntimeCLI,
 ) (string, error) {
-	refs, err := deps.Git.Refs(repo.AbsPath)
+	ctx, cancel := WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	refs, err := deps.Git.Refs(ctx, repo.AbsPath)
 	if err != nil {
 		return "", fmt.Errorf("unable to open repo: %w", err)
 	}
@@ -219,3 +223,37 @@ func SelectBranch(
 	branchName := strings.Split(selected, delimiter)[1]
 	return branchName, nil
 }
+
+// SelectIndexes returns the indexes of interactively selected lines. Lines
+// are prefixed with a hidden index, so they don't need to be unique.
+func SelectIndexes(lines []string, prompt string, multi bool) ([]int, error) {
+	delimiter := "\t"
+	buffer := bytes.Buffer{}
+	for idx, line := range lines {
+		buffer.WriteString(strconv.Itoa(idx) + delimiter + line + "\n")
+	}
+
+	finder := fzf.New("--delimiter="+delimiter, "--with-nth=2..")
+	finder.WithPrompt(prompt)
+	if multi {
+		finder.WithMulti()
+	}
+	selected, err := finder.Run(buffer)
+	if err != nil {
+		return nil, err
+	}
+	if selected == "" {
+		return nil, errors.New("nothing selected")
+	}
+
+	indexes := []int{}
+	for _, line := range strings.Split(selected, "\n") {
+		index, _, _ := strings.Cut(line, delimiter)
+		idx, err := strconv.Atoi(index)
+		if err != nil || idx < 0 || idx >= len(lines) {
+			return nil, fmt.Errorf("unable to parse selection %q", line)
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes, nil
+}
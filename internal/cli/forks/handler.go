@@ -0,0 +1,265 @@
+package forks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+// Options are the sync-forks command options.
+type Options struct {
+	// Push pushes the synchronized default branch to origin.
+	Push bool
+}
+
+// ExecSyncForks fast-forwards the default branch of project forks, or a
+// specific repo, from their upstream remote. Repositories without an
+// upstream remote are skipped.
+//
+// Args: (optional)
+//   - project name
+//   - repo
+func ExecSyncForks(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, repo, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	if repo != nil {
+		// Synchronize a single repository.
+		resp := syncRepo(project, *repo, opts, deps)
+		if resp.skipped {
+			return fmt.Errorf("%s has no %s remote", repo.GetName(), domain.RemoteUpstream)
+		}
+		fmt.Println(resp)
+		return resp.error
+	}
+
+	// Synchronize all project's forks.
+	errs := syncProjectRepos(project, opts, deps)
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type SyncResponse struct {
+	branch     string
+	upstream   string
+	output     string
+	skipped    bool
+	title      lipgloss.Style
+	error      error
+	errorStyle lipgloss.Style
+}
+
+func (r SyncResponse) String() string {
+	if r.error != nil {
+		return fmt.Sprintf("%s %s", r.title, r.errorStyle.Render(r.error.Error()))
+	}
+
+	return fmt.Sprintf(
+		"%s [%s <- %s] %s",
+		r.title.Render(),
+		r.branch,
+		r.upstream,
+		r.output,
+	)
+}
+
+func syncProjectRepos(project domain.Project, opts Options, deps types.RuntimeCLI) []error {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	deps.Git = deps.Git.Batch()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, repo := range project.Repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := syncRepo(project, repo, opts, deps)
+			if resp.skipped {
+				return
+			}
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			if resp.error != nil {
+				mu.Lock()
+				errList = append(errList, resp.error)
+				mu.Unlock()
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		errs := syncProjectRepos(subProject, opts, deps)
+		errList = append(errList, errs...)
+	}
+	return errList
+}
+
+func syncRepo(
+	project domain.Project,
+	repo domain.Repository,
+	opts Options,
+	deps types.RuntimeCLI,
+) SyncResponse {
+	resp := SyncResponse{
+		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		if repo.Remotes[domain.RemoteUpstream] == "" {
+			resp.skipped = true
+			return resp
+		}
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Pull)
+	defer cancel()
+
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	remotes, err := gitRepo.Remotes(ctx)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	upstreamURL := repo.Remotes[domain.RemoteUpstream]
+	hasUpstream := slices.Contains(remotes, domain.RemoteUpstream)
+	if upstreamURL == "" && !hasUpstream {
+		resp.skipped = true
+		return resp
+	}
+
+	// Keep the upstream remote in sync with config.
+	switch {
+	case upstreamURL == "":
+	case hasUpstream:
+		err = deps.Git.SetRemoteURL(ctx, repo.AbsPath, domain.RemoteUpstream, upstreamURL)
+	default:
+		err = deps.Git.AddRemote(ctx, repo.AbsPath, domain.RemoteUpstream, upstreamURL)
+	}
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	if _, err := deps.Git.Fetch(ctx, repo.AbsPath); err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	resp.branch, err = deps.Git.DefaultBranch(ctx, repo.AbsPath, domain.RemoteOrigin)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	upstreamBranch, err := deps.Git.DefaultBranch(ctx, repo.AbsPath, domain.RemoteUpstream)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.upstream = domain.RemoteUpstream + "/" + upstreamBranch
+	if !gitRepo.IsLocalBranch(ctx, resp.branch) {
+		resp.error = types.NewWarning("no local %s branch, skipped", resp.branch)
+		return resp
+	}
+
+	icons := deps.Settings.Icons
+	target := "refs/remotes/" + resp.upstream
+	ahead, behind, err := deps.Git.Diff(ctx, repo.AbsPath, resp.branch, target)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	if ahead > 0 {
+		resp.error = types.NewWarning(
+			"diverged from %s (%s%d %s%d), skipped",
+			resp.upstream, icons.Ahead, ahead, icons.Behind, behind,
+		)
+		return resp
+	}
+
+	summary := []string{"up to date"}
+	output := []string{}
+	if behind > 0 {
+		out, err := deps.Git.FastForward(ctx, repo.AbsPath, resp.branch, target)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		summary = []string{fmt.Sprintf("%s%d", icons.Behind, behind)}
+		output = append(output, out)
+	}
+
+	if opts.Push {
+		pushed, out, err := pushOrigin(ctx, repo, resp.branch, deps)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		if pushed > 0 {
+			summary = append(summary, fmt.Sprintf("pushed %s%d", icons.Ahead, pushed))
+			output = append(output, out)
+		}
+	}
+
+	resp.output = deps.Theme.Provider.Render("(" + strings.Join(summary, ", ") + ")")
+	if len(output) > 0 {
+		resp.output += " " + deps.Theme.GitOutput.Render(strings.Join(output, "\n"))
+	}
+	return resp
+}
+
+// pushOrigin pushes a branch to origin if it's ahead, and returns the number
+// of commits pushed.
+func pushOrigin(
+	ctx context.Context,
+	repo domain.Repository,
+	branch string,
+	deps types.RuntimeCLI,
+) (int, string, error) {
+	originBranch := "refs/remotes/" + domain.RemoteOrigin + "/" + branch
+	ahead, behind, err := deps.Git.Diff(ctx, repo.AbsPath, branch, originBranch)
+	if err != nil {
+		return 0, "", err
+	}
+	if behind > 0 {
+		return 0, "", errors.New(domain.RemoteOrigin + "/" + branch + " has diverged, not pushed")
+	}
+	if ahead == 0 {
+		return 0, "", nil
+	}
+	output, err := deps.Git.Push(ctx, repo.AbsPath, git.PushOptions{
+		Remote: domain.RemoteOrigin,
+		Branch: branch,
+	})
+	return ahead, output, err
+}
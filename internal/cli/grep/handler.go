@@ -0,0 +1,187 @@
+package grep
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+const defaultEditor = "vi"
+
+// Options are the grep command options.
+type Options struct {
+	// Ref searches a branch, tag or commit instead of the working tree.
+	Ref string
+	// FilesOnly lists matching file names, without lines.
+	FilesOnly bool
+	// Finder selects a match interactively, and opens it in $EDITOR.
+	Finder bool
+}
+
+// repoMatches are the search results of a single repository.
+type repoMatches struct {
+	name    string
+	repo    domain.Repository
+	matches []git.GrepMatch
+}
+
+// ExecGrep searches all cloned repositories of a project, and prints
+// matches prefixed by repository name.
+//
+// Args:
+//   - pattern
+//   - project name (optional)
+func ExecGrep(opts Options, args []string, deps types.RuntimeCLI) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing search pattern")
+	}
+	pattern := args[0]
+	project, _, err := cli.ParseArgs(args[1:], true, deps)
+	if err != nil {
+		return err
+	}
+
+	results, errs := grepProject(project, git.GrepOptions{
+		Pattern:   pattern,
+		Ref:       opts.Ref,
+		FilesOnly: opts.FilesOnly,
+	}, deps)
+
+	if opts.Finder {
+		if err := selectMatch(project, results, deps); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range results {
+			for _, match := range result.matches {
+				fmt.Println(renderMatch(result.name, match, deps))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// grepProject searches cloned repositories of a project and its
+// sub-projects, results are ordered by namespaced repository name.
+func grepProject(
+	project domain.Project,
+	opts git.GrepOptions,
+	deps types.RuntimeCLI,
+) ([]repoMatches, []error) {
+	names := project.ListReposWithNamespace()
+	results := make([]repoMatches, len(names))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+	)
+	for idx, name := range names {
+		repo, found := project.GetRepo(name, "")
+		if !found || repo.State != domain.RepoStateOK {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+			defer cancel()
+
+			matches, err := deps.Git.Grep(ctx, repo.AbsPath, opts)
+			if err != nil {
+				mu.Lock()
+				errList = append(errList, cli.RepoError(err, repo))
+				mu.Unlock()
+				return
+			}
+			results[idx] = repoMatches{name: name, repo: repo, matches: matches}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+	return results, errList
+}
+
+// renderMatch returns a match prefixed by repository name, file and line.
+func renderMatch(name string, match git.GrepMatch, deps types.RuntimeCLI) string {
+	prefix := fmt.Sprintf(
+		"%s:%s",
+		deps.Theme.RepoTitle.Render(name),
+		deps.Theme.RepoPath.Render(match.File),
+	)
+	if match.Line == 0 {
+		return prefix
+	}
+	return fmt.Sprintf(
+		"%s:%s:%s",
+		prefix,
+		deps.Theme.Provider.Render(strconv.Itoa(match.Line)),
+		match.Text,
+	)
+}
+
+// selectMatch selects a match interactively, and opens it in $EDITOR.
+func selectMatch(project domain.Project, results []repoMatches, deps types.RuntimeCLI) error {
+	type location struct {
+		repo  domain.Repository
+		match git.GrepMatch
+	}
+
+	locations := []location{}
+	lines := []string{}
+	for _, result := range results {
+		for _, match := range result.matches {
+			lines = append(lines, renderMatch(result.name, match, deps))
+			locations = append(locations, location{result.repo, match})
+		}
+	}
+	if len(locations) == 0 {
+		return errors.New("no matches found")
+	}
+
+	prompt := fmt.Sprintf("[%s] grep> ", project.Name)
+	indexes, err := cli.SelectIndexes(lines, prompt, false)
+	if err != nil {
+		return fmt.Errorf("unable to select a match: %w", err)
+	}
+
+	loc := locations[indexes[0]]
+	return openEditor(filepath.Join(loc.repo.AbsPath, loc.match.File), loc.match.Line)
+}
+
+// openEditor opens a file in $EDITOR, at line if provided.
+func openEditor(file string, line int) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+	args := editor[1:]
+	if line > 0 {
+		args = append(args, "+"+strconv.Itoa(line))
+	}
+	args = append(args, file)
+
+	cmd := exec.Command(editor[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to open editor: %w", err)
+	}
+	return nil
+}
@@ -1,16 +1,21 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
 
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli/config"
 	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
 )
 
 const (
@@ -23,6 +28,16 @@ var (
 	ErrNotCloned     = fmt.Errorf("not cloned")
 )
 
+// causeHints are suggested fixes for known causes of git failures.
+var causeHints = map[error]string{
+	git.ErrAuthFailed:      "check your credentials, or that your SSH key is loaded (ssh-add -l)",
+	git.ErrHostUnreachable: "check your network connection and that the remote host is up",
+	git.ErrRepoNotFound:    "verify the remote URL, the repository may have been moved or deleted",
+	git.ErrNonFastForward:  "branches have diverged, rebase or merge manually",
+	git.ErrLocalChanges:    "commit or stash your local changes first",
+	git.ErrLockFile:        "make sure no other git process is running, then remove the .lock file",
+}
+
 func GetTheme(themeSettings domain.Theme) (config.Theme, error) {
 	theme := config.NewThemeDefault()
 	if err := theme.ParseConfig(themeSettings); err != nil {
@@ -47,37 +62,97 @@ func AbortOnRepoState(repo domain.Repository, style lipgloss.Style) error {
 	return RepoError(err, repo)
 }
 
+// RepoError returns a repository error, cancelled and timed-out operations
+// are typed distinctly.
 func RepoError(err error, repo domain.Repository) types.Warning {
-	return types.Warning{
+	w := types.Warning{
 		Title:  repo.GetName(),
 		Reason: err.Error(),
 		Dir:    repo.AbsPath,
+		Err:    err,
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		w.Type = types.CancelledType
+		w.Reason = "timed out"
+	case errors.Is(err, context.Canceled):
+		w.Type = types.CancelledType
+		w.Reason = "cancelled"
+	}
+	return w
+}
+
+// WithTimeout returns a context bounded by timeout, a negative timeout
+// disables it.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, timeout)
 }
 
+// RenderErrors prints a list of errors grouped by their known git cause with
+// a suggested fix, and cancelled operations separately.
 func RenderErrors(errs []error, excludeWarnings bool) error {
-	out := []string{}
-	count := 0
+	other := []string{}
+	cancelled := []string{}
+	grouped := map[error][]string{}
 	for _, err := range errs {
-		if excludeWarnings {
-			// nolint:errorlint
-			if e, ok := err.(*types.Warning); ok && e.Type == types.WarningType {
+		switch errorType(err) {
+		case types.WarningType:
+			if excludeWarnings {
 				continue
 			}
+		case types.CancelledType:
+			cancelled = append(cancelled, fmt.Sprintf("  - %s", err))
+			continue
+		}
+		if cause := git.Cause(err); cause != nil {
+			grouped[cause] = append(grouped[cause], fmt.Sprintf("  - %s", err))
+			continue
 		}
-		count++
-		out = append(out, fmt.Sprintf("  - %s", err))
+		other = append(other, fmt.Sprintf("  - %s", err))
 	}
-	if count < 1 {
+	if len(other) == 0 && len(cancelled) == 0 && len(grouped) == 0 {
 		return nil
 	}
-	title := "error" + strings.Repeat("s", min(1, count-1))
-	out = append([]string{"", fmt.Sprintf("%d %s:", count, title), ""}, out...)
+
+	out := []string{}
+	if len(other) > 0 {
+		title := "error" + strings.Repeat("s", min(1, len(other)-1))
+		out = append(out, "", fmt.Sprintf("%d %s:", len(other), title), "")
+		out = append(out, other...)
+	}
+	for _, cause := range git.Causes {
+		if len(grouped[cause]) == 0 {
+			continue
+		}
+		out = append(out, "", fmt.Sprintf("%d %s:", len(grouped[cause]), cause), "")
+		out = append(out, grouped[cause]...)
+		out = append(out, "", "  hint: "+causeHints[cause])
+	}
+	if len(cancelled) > 0 {
+		out = append(out, "", fmt.Sprintf("%d cancelled:", len(cancelled)), "")
+		out = append(out, cancelled...)
+	}
 	out = append(out, "")
 	fmt.Println(strings.Join(out, "\n"))
 	return errors.New("completed with errors")
 }
 
+// errorType returns the warning type of an error, defaults to ErrorType.
+func errorType(err error) types.Type {
+	var warning types.Warning
+	if errors.As(err, &warning) {
+		return warning.Type
+	}
+	var warningPtr *types.Warning
+	if errors.As(err, &warningPtr) {
+		return warningPtr.Type
+	}
+	return types.ErrorType
+}
+
 // ProjectTitleWithBullet returns a formatted project title.
 func ProjectTitleWithBullet(project domain.Project, theme config.Theme) string {
 	return fmt.Sprintf(
@@ -146,6 +221,117 @@ func Path(path, homeDir string) string {
 	return path
 }
 
+// Table returns a borderless table with column separators.
+func Table(headers []string, rows [][]string, theme config.Theme) *table.Table {
+	return table.New().
+		Border(theme.TableBorder).
+		BorderStyle(theme.TableBorderStyle).
+		BorderTop(false).
+		BorderRight(false).
+		BorderBottom(false).
+		BorderLeft(false).
+		BorderColumn(true).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(theme.TableRowStyle)
+}
+
+// RepoResult is the outcome of a command on a single repository, rendered as
+// a row by ResultTable.
+type RepoResult struct {
+	Name string
+	Repo domain.Repository
+	// Details are the columns between the name and status, e.g. a commit.
+	Details []string
+	Status  string
+	// Skipped renders status as the reason the repository was skipped.
+	Skipped bool
+	// Failed renders status as a problem, without reporting an error.
+	Failed bool
+	Error  error
+}
+
+// RepoResults returns an empty result for each repository of a project,
+// ordered by namespaced repository name.
+func RepoResults(project domain.Project) []RepoResult {
+	names := project.ListReposWithNamespace()
+	results := make([]RepoResult, 0, len(names))
+	for _, name := range names {
+		if repo, found := project.GetRepo(name, ""); found {
+			results = append(results, RepoResult{Name: name, Repo: repo})
+		}
+	}
+	return results
+}
+
+// CheckState reports whether the repository is cloned and valid, otherwise
+// the result is marked as skipped or failed.
+func (r *RepoResult) CheckState() bool {
+	switch r.Repo.State {
+	case domain.RepoStateOK:
+		return true
+	case domain.RepoStateNoLocal:
+		r.Skipped, r.Status = true, ErrNotCloned.Error()
+	default:
+		r.Error = ErrNotRepository
+	}
+	return false
+}
+
+// ResultTable returns a table of repository results, and their errors.
+func ResultTable(
+	headers []string,
+	results []RepoResult,
+	theme config.Theme,
+) (*table.Table, []error) {
+	rows := make([][]string, 0, len(results))
+	errList := []error{}
+	for _, res := range results {
+		status := res.Status
+		switch {
+		case res.Error != nil:
+			errList = append(errList, RepoError(res.Error, res.Repo))
+			if status == "" {
+				status = "failed"
+			}
+			status = theme.Error.Render(status)
+		case res.Failed:
+			status = theme.Error.Render(status)
+		case res.Skipped:
+			status = theme.Provider.Render("skipped, " + status)
+		default:
+			status = theme.GitOutput.Render(status)
+		}
+		row := append([]string{theme.RepoTitle.Render(res.Name)}, res.Details...)
+		rows = append(rows, append(row, status))
+	}
+	return Table(headers, rows, theme), errList
+}
+
+// ForEach calls fn concurrently for each index below n, running at most
+// workers calls at a time, and at least one.
+func ForEach(n, workers int, fn func(idx int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(workers, 1))
+	for idx := range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(idx)
+		}()
+	}
+	wg.Wait()
+}
+
+// ShortHash returns the abbreviated form of a commit hash.
+func ShortHash(hash string) string {
+	return hash[:min(7, len(hash))]
+}
+
 // GetMaxLen returns length of the widest repo directory in a project.
 func GetMaxLen(project domain.Project) int {
 	maxLen := 0
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rafi/gits/domain"
+)
+
+func TestForEach(t *testing.T) {
+	tests := []struct {
+		n, workers int
+		wantMax    int32
+	}{
+		{n: 0, workers: 4, wantMax: 0},
+		{n: 1, workers: 4, wantMax: 1},
+		{n: 5, workers: 4, wantMax: 4},
+		{n: 12, workers: 4, wantMax: 4},
+		{n: 3, workers: 1, wantMax: 1},
+		{n: 3, workers: 0, wantMax: 1},
+	}
+	for _, tt := range tests {
+		var calls, running, peak atomic.Int32
+		seen := make([]bool, tt.n)
+		ForEach(tt.n, tt.workers, func(idx int) {
+			calls.Add(1)
+			seen[idx] = true
+			now := running.Add(1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			// Give other calls a chance to run concurrently.
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+		})
+		if int(calls.Load()) != tt.n {
+			t.Errorf("ForEach(%d, %d) called fn %d times", tt.n, tt.workers, calls.Load())
+		}
+		for idx, ok := range seen {
+			if !ok {
+				t.Errorf("ForEach(%d, %d) skipped index %d", tt.n, tt.workers, idx)
+			}
+		}
+		if got := peak.Load(); got != tt.wantMax {
+			t.Errorf("ForEach(%d, %d) ran %d calls at once, want %d", tt.n, tt.workers, got, tt.wantMax)
+		}
+	}
+}
+
+func TestCheckState(t *testing.T) {
+	tests := []struct {
+		state       domain.RepoState
+		want        bool
+		wantSkipped bool
+		wantErr     error
+	}{
+		{state: domain.RepoStateOK, want: true},
+		{state: domain.RepoStateNoLocal, wantSkipped: true},
+		{state: domain.RepoStateError, wantErr: ErrNotRepository},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			res := RepoResult{Repo: domain.Repository{State: tt.state}}
+			if got := res.CheckState(); got != tt.want {
+				t.Errorf("CheckState() = %v, want %v", got, tt.want)
+			}
+			if res.Skipped != tt.wantSkipped {
+				t.Errorf("Skipped = %v, want %v", res.Skipped, tt.wantSkipped)
+			}
+			if !errors.Is(res.Error, tt.wantErr) {
+				t.Errorf("Error = %v, want %v", res.Error, tt.wantErr)
+			}
+		})
+	}
+}
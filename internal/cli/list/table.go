@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"path/filepath"
 
-	"github.com/charmbracelet/lipgloss/table"
-
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/cli/config"
@@ -46,19 +44,7 @@ func listTable(projects domain.ProjectListKeyed, deps types.RuntimeCLI) error {
 }
 
 func printTable(headers []string, rows [][]string, theme config.Theme) error {
-	t := table.New().
-		Border(theme.TableBorder).
-		BorderStyle(theme.TableBorderStyle).
-		BorderTop(false).
-		BorderRight(false).
-		BorderBottom(false).
-		BorderLeft(false).
-		BorderColumn(true).
-		Headers(headers...).
-		Rows(rows...).
-		StyleFunc(theme.TableRowStyle)
-
-	fmt.Println(t)
+	fmt.Println(cli.Table(headers, rows, theme))
 	return nil
 }
 
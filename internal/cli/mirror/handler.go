@@ -0,0 +1,162 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+)
+
+// Options are the mirror command options.
+type Options struct {
+	// Dest is the backup directory, mirrors are kept in a sub-directory per
+	// project.
+	Dest string
+}
+
+// ExecMirror maintains bare mirror clones of all project repositories,
+// including ones not cloned locally, and writes a summary manifest.
+//
+// Args: (optional)
+//   - project name
+func ExecMirror(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	dest, err := homedir.Expand(opts.Dest)
+	if err != nil {
+		return fmt.Errorf("unable to expand path: %w", err)
+	}
+	dest = filepath.Join(dest, project.Name)
+
+	deps.Git = deps.Git.Batch()
+	entries, errs := mirrorProjectRepos(project, dest, dest, deps)
+
+	manifest := newManifest(project.Name, entries)
+	if err := manifest.Save(dest); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type MirrorResponse struct {
+	entry      ManifestEntry
+	output     string
+	title      lipgloss.Style
+	error      error
+	errorStyle lipgloss.Style
+}
+
+func (r MirrorResponse) String() string {
+	if r.error != nil {
+		return fmt.Sprintf("%s %s", r.title, r.errorStyle.Render(r.error.Error()))
+	}
+
+	return fmt.Sprintf(
+		"%s %s",
+		r.title.Render(),
+		r.output,
+	)
+}
+
+// Entry returns the manifest entry of a mirrored repository.
+func (r MirrorResponse) Entry() ManifestEntry {
+	entry := r.entry
+	entry.UpdatedAt = time.Now()
+	if r.error != nil {
+		entry.Action = ActionFailed
+		entry.Error = r.error.Error()
+	}
+	return entry
+}
+
+func mirrorProjectRepos(project domain.Project, root, dest string, deps types.RuntimeCLI) ([]ManifestEntry, []error) {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		entries = make([]ManifestEntry, 0, len(project.Repos))
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, repo := range project.Repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := mirrorRepo(project, repo, root, dest, deps)
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			mu.Lock()
+			entries = append(entries, resp.Entry())
+			if resp.error != nil {
+				errList = append(errList, resp.error)
+			}
+			mu.Unlock()
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		subDest := filepath.Join(dest, subProject.Name)
+		subEntries, errs := mirrorProjectRepos(subProject, root, subDest, deps)
+		entries = append(entries, subEntries...)
+		errList = append(errList, errs...)
+	}
+	return entries, errList
+}
+
+func mirrorRepo(project domain.Project, repo domain.Repository, root, dest string, deps types.RuntimeCLI) MirrorResponse {
+	path := filepath.Join(dest, repo.GetNameWithNamespace()+".git")
+	relPath, _ := filepath.Rel(root, path)
+	resp := MirrorResponse{
+		entry: ManifestEntry{
+			Name:   repo.GetNameWithNamespace(),
+			Source: repo.Src,
+			Path:   relPath,
+		},
+		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+	if repo.Src == "" {
+		resp.error = cli.RepoError(errors.New("no remote source"), repo)
+		return resp
+	}
+
+	var err error
+	if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+		defer cancel()
+		resp.entry.Action = ActionCloned
+		_, err = deps.Git.Mirror(ctx, repo.Src, path)
+	} else {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+		defer cancel()
+		resp.entry.Action = ActionUpdated
+		_, err = deps.Git.UpdateMirror(ctx, path)
+	}
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.output = deps.Theme.GitOutput.Render(string(resp.entry.Action))
+	return resp
+}
@@ -0,0 +1,83 @@
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rafi/gits/internal/fileutil"
+)
+
+const manifestFilename = "manifest.json"
+
+// Action is the outcome of mirroring a single repository.
+type Action string
+
+var (
+	ActionCloned  Action = "cloned"
+	ActionUpdated Action = "updated"
+	ActionFailed  Action = "failed"
+)
+
+// Manifest summarizes the last mirror run of a project.
+type Manifest struct {
+	Project   string          `json:"project"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Cloned    int             `json:"cloned"`
+	Updated   int             `json:"updated"`
+	Failed    int             `json:"failed"`
+	Repos     []ManifestEntry `json:"repos"`
+}
+
+// ManifestEntry is a single mirrored repository.
+type ManifestEntry struct {
+	Name      string    `json:"name"`
+	Source    string    `json:"source"`
+	Path      string    `json:"path"`
+	Action    Action    `json:"action"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// newManifest returns a manifest of entries sorted by path.
+func newManifest(project string, entries []ManifestEntry) Manifest {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	manifest := Manifest{
+		Project:   project,
+		CreatedAt: time.Now(),
+		Repos:     entries,
+	}
+	for _, entry := range entries {
+		switch entry.Action {
+		case ActionCloned:
+			manifest.Cloned++
+		case ActionUpdated:
+			manifest.Updated++
+		case ActionFailed:
+			manifest.Failed++
+		}
+	}
+	return manifest
+}
+
+// Save writes the manifest into the mirrors directory.
+func (m Manifest) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	// A failed run must never leave a partially written manifest.
+	path := filepath.Join(dir, manifestFilename)
+	if err := fileutil.WriteFileAtomic(path, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
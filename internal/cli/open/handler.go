@@ -0,0 +1,135 @@
+package open
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/providers"
+)
+
+// Options are the open command options.
+type Options struct {
+	// Branch opens the current branch.
+	Branch bool
+	// Commits opens the commits of the current branch.
+	Commits bool
+	// Pulls opens the pull or merge requests.
+	Pulls bool
+	// CI opens the pipelines.
+	CI bool
+	// Print prints the URL, without launching a browser.
+	Print bool
+}
+
+// page returns the selected page, defaults to repository home.
+func (o Options) page() providers.Page {
+	switch {
+	case o.Branch:
+		return providers.PageBranch
+	case o.Commits:
+		return providers.PageCommits
+	case o.Pulls:
+		return providers.PagePulls
+	case o.CI:
+		return providers.PageCI
+	}
+	return providers.PageHome
+}
+
+// ExecOpen prints a repository web page URL, and opens it in a browser.
+//
+// Args: (optional)
+//   - project name
+//   - repo or sub-project name
+func ExecOpen(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, repo, err := cli.ParseArgs(args, false, deps)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return errors.New("missing repo name")
+	}
+
+	webURL, err := repoWebURL(*repo, deps)
+	if err != nil {
+		return err
+	}
+	provider := providers.DetectProvider(webURL)
+	if provider == "" && project.Source != nil {
+		provider = providers.Provider(project.Source.Type)
+	}
+
+	page := opts.page()
+	branch := ""
+	if (page == providers.PageBranch || page == providers.PageCommits) && repo.State == domain.RepoStateOK {
+		gitRepo, err := deps.Git.Open(repo.AbsPath)
+		if err != nil {
+			return err
+		}
+		branch, _ = gitRepo.CurrentBranch(deps.Context)
+		if branch == "HEAD" {
+			branch = ""
+		}
+	}
+	if page == providers.PageBranch && branch == "" {
+		return fmt.Errorf("%s has no current branch, not cloned or detached HEAD", repo.GetName())
+	}
+	pageURL, err := providers.PageURL(webURL, provider, page, branch)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(pageURL)
+	if opts.Print {
+		return nil
+	}
+	return launch(pageURL)
+}
+
+// repoWebURL returns the provider web URL of a repository, or derives it
+// from its remote URL.
+func repoWebURL(repo domain.Repository, deps types.RuntimeCLI) (string, error) {
+	if repo.URL != "" {
+		return repo.URL, nil
+	}
+	remote := repo.Src
+	if repo.State == domain.RepoStateOK {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+		defer cancel()
+		if url, err := deps.Git.Remote(ctx, repo.AbsPath); err == nil && url != "" {
+			remote = url
+		}
+	}
+	if remote == "" {
+		return "", fmt.Errorf("%s has no remote", repo.GetName())
+	}
+	return providers.WebURL(remote)
+}
+
+// launch opens a URL with $BROWSER, or the platform default opener.
+func launch(url string) error {
+	var cmd *exec.Cmd
+	if browser := strings.Fields(strings.Split(os.Getenv("BROWSER"), ":")[0]); len(browser) > 0 {
+		cmd = exec.Command(browser[0], append(browser[1:], url)...)
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to open browser: %w", err)
+	}
+	return cmd.Process.Release()
+}
@@ -1,9 +1,11 @@
 package orphan
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/karrick/godirwalk"
 	log "github.com/sirupsen/logrus"
@@ -27,7 +29,7 @@ func ExecOrphan(args []string, deps types.RuntimeCLI) error {
 		return err
 	}
 
-	repos, err := findOrphanedRepos(project, deps.Git)
+	repos, err := findOrphanedRepos(deps.Context, project, deps.Git, deps.Settings.Timeouts.Status)
 	if err != nil {
 		return err
 	}
@@ -55,8 +57,8 @@ func makeRepoMap(project domain.Project, repoMap map[string]bool) {
 }
 
 // findOrphanedRepos scans the project's directory for repositories that are not
-// known to the project provider.
-func findOrphanedRepos(project domain.Project, gitClient git.Git) ([]domain.Repository, error) {
+// known to the project provider. Each repository is inspected within timeout.
+func findOrphanedRepos(ctx context.Context, project domain.Project, gitClient git.Git, timeout time.Duration) ([]domain.Repository, error) {
 	orphanRepos := []domain.Repository{}
 	knownRepos := make(map[string]bool)
 	makeRepoMap(project, knownRepos)
@@ -77,7 +79,9 @@ func findOrphanedRepos(project domain.Project, gitClient git.Git) ([]domain.Repo
 			}
 			// Add unknown repository to the list.
 			if _, known := knownRepos[path]; !known {
-				repo, err := providers.NewFilesystemRepo(path, "", gitClient)
+				repoCtx, cancel := cli.WithTimeout(ctx, timeout)
+				repo, err := providers.NewFilesystemRepo(repoCtx, path, "", gitClient)
+				cancel()
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,227 @@
+package prune
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+var resultHeaders = []string{"REPO", "BRANCH", "REASON", "RESULT"}
+
+// Options are the prune-branches command options.
+type Options struct {
+	// DryRun lists prunable branches, without deleting them.
+	DryRun bool
+	// Finder selects branches to delete interactively.
+	Finder bool
+	// Force deletes branches whose upstream is gone, even with commits not
+	// found on any remote.
+	Force bool
+}
+
+// candidate is a prunable local branch of a repository.
+type candidate struct {
+	name   string
+	repo   domain.Repository
+	branch string
+	reason string
+	// gone branches are usually squash-merged, and not fully merged.
+	gone bool
+	// unpushed branches have commits not found on any remote.
+	unpushed bool
+}
+
+// ExecPruneBranches deletes local branches fully merged into the default
+// branch, or whose upstream is gone, across project repositories.
+//
+// Args: (optional)
+//   - project name
+func ExecPruneBranches(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+	deps.Git = deps.Git.Batch()
+
+	candidates, errs := collectCandidates(project, deps)
+	if opts.Finder && !opts.DryRun && len(candidates) > 0 {
+		candidates, err = selectCandidates(project, candidates, deps)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	if len(candidates) == 0 {
+		fmt.Println("  " + deps.Theme.Provider.Render("no branches to prune"))
+	} else {
+		results := make([]cli.RepoResult, 0, len(candidates))
+		for _, c := range candidates {
+			res := cli.RepoResult{
+				Name:    c.name,
+				Repo:    c.repo,
+				Details: []string{c.branch, c.reason},
+				Status:  "would delete",
+			}
+			switch {
+			case c.unpushed && !opts.Force:
+				res.Skipped, res.Status = true, "requires --force"
+			case !opts.DryRun:
+				res.Status = "deleted"
+				if res.Error = deleteBranch(c, deps); res.Error != nil {
+					res.Status = ""
+				}
+			}
+			results = append(results, res)
+		}
+		table, deleteErrs := cli.ResultTable(resultHeaders, results, deps.Theme)
+		fmt.Println(table)
+		errs = append(errs, deleteErrs...)
+	}
+
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// collectCandidates fetches cloned repositories and returns their prunable
+// branches, ordered by namespaced repository name.
+func collectCandidates(project domain.Project, deps types.RuntimeCLI) ([]candidate, []error) {
+	names := project.ListReposWithNamespace()
+	results := make([][]candidate, len(names))
+	repoErrs := make([]error, len(names))
+	cli.ForEach(len(names), deps.Settings.WorkerCount, func(idx int) {
+		repo, found := project.GetRepo(names[idx], "")
+		if !found || repo.State != domain.RepoStateOK {
+			return
+		}
+		candidates, err := repoCandidates(names[idx], repo, deps)
+		if err != nil {
+			repoErrs[idx] = cli.RepoError(err, repo)
+			return
+		}
+		results[idx] = candidates
+	})
+
+	errList := slices.DeleteFunc(repoErrs, func(err error) bool { return err == nil })
+	candidates := []candidate{}
+	for _, result := range results {
+		candidates = append(candidates, result...)
+	}
+	return candidates, errList
+}
+
+// repoCandidates returns local branches merged into the default branch, or
+// whose upstream is gone after pruning. The default and current branches,
+// and branches without upstream or commits of their own, are never pruned.
+// Gone branches with commits not found on any remote are marked unpushed.
+func repoCandidates(name string, repo domain.Repository, deps types.RuntimeCLI) ([]candidate, error) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancel()
+
+	if _, err := deps.Git.Fetch(ctx, repo.AbsPath); err != nil {
+		return nil, err
+	}
+	defaultBranch, err := deps.Git.DefaultBranch(ctx, repo.AbsPath, domain.RemoteOrigin)
+	if err != nil {
+		return nil, err
+	}
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	current, _ := gitRepo.CurrentBranch(ctx)
+
+	target := "refs/remotes/" + domain.RemoteOrigin + "/" + defaultBranch
+	branches, err := deps.Git.LocalBranches(ctx, repo.AbsPath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var unpushed []git.UnpushedBranch
+	if slices.ContainsFunc(branches, func(b git.LocalBranch) bool { return b.Gone && !b.Merged }) {
+		if unpushed, err = deps.Git.UnpushedBranches(ctx, repo.AbsPath); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := []candidate{}
+	for _, branch := range branches {
+		if branch.Name == defaultBranch || branch.Name == current {
+			continue
+		}
+		// Branches just created from the default branch look merged.
+		if branch.Empty && branch.Upstream == "" {
+			continue
+		}
+		reasons := []string{}
+		if branch.Merged {
+			reasons = append(reasons, "merged")
+		}
+		if branch.Gone {
+			reasons = append(reasons, "gone")
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		isUnpushed := !branch.Merged && slices.ContainsFunc(unpushed, func(u git.UnpushedBranch) bool {
+			return !u.Detached && u.Name == branch.Name
+		})
+		if isUnpushed {
+			reasons = append(reasons, "unpushed commits")
+		}
+		candidates = append(candidates, candidate{
+			name:     name,
+			repo:     repo,
+			branch:   branch.Name,
+			reason:   strings.Join(reasons, ", "),
+			gone:     branch.Gone,
+			unpushed: isUnpushed,
+		})
+	}
+	return candidates, nil
+}
+
+// selectCandidates selects branches to delete interactively.
+func selectCandidates(
+	project domain.Project,
+	candidates []candidate,
+	deps types.RuntimeCLI,
+) ([]candidate, error) {
+	lines := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		lines = append(lines, fmt.Sprintf(
+			"%s %s %s",
+			deps.Theme.RepoTitle.Render(c.name),
+			c.branch,
+			deps.Theme.Provider.Render("("+c.reason+")"),
+		))
+	}
+	prompt := fmt.Sprintf("[%s] prune> ", project.Name)
+	indexes, err := cli.SelectIndexes(lines, prompt, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select branches: %w", err)
+	}
+
+	result := make([]candidate, 0, len(indexes))
+	for _, idx := range indexes {
+		result = append(result, candidates[idx])
+	}
+	return result, nil
+}
+
+// deleteBranch deletes a branch, forced only if its upstream is gone, since
+// those are usually squash-merged and not fully merged. Gone branches with
+// unpushed commits only reach here with --force.
+func deleteBranch(c candidate, deps types.RuntimeCLI) error {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	_, err := deps.Git.DeleteBranch(ctx, c.repo.AbsPath, c.branch, c.gone)
+	return err
+}
@@ -2,6 +2,7 @@ package pull
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
@@ -9,14 +10,20 @@ import (
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
 )
 
-// ExecPull runs pull --ff-only on project repositories, or on a specific repo.
+// ExecPull pulls project repositories, or a specific repo. Pull options
+// override the ones configured for project and repositories, the default
+// strategy is fast-forward only.
 //
 // Args: (optional)
 //   - project name
 //   - repo
-func ExecPull(args []string, deps types.RuntimeCLI) error {
+func ExecPull(opts domain.PullOptions, args []string, deps types.RuntimeCLI) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
 	project, repo, err := cli.ParseArgs(args, true, deps)
 	if err != nil {
 		return err
@@ -24,13 +31,13 @@ func ExecPull(args []string, deps types.RuntimeCLI) error {
 
 	if repo != nil {
 		// Pull a single repository.
-		resp := pullRepo(project, *repo, deps)
+		resp := pullRepo(project, *repo, opts, deps)
 		fmt.Println(resp)
 		return resp.error
 	}
 
 	// Pull all project's repositories.
-	errs := pullProjectRepos(project, deps)
+	errs := pullProjectRepos(project, opts, deps)
 	if len(errs) > 0 {
 		return cli.RenderErrors(errs, true)
 	}
@@ -40,6 +47,7 @@ func ExecPull(args []string, deps types.RuntimeCLI) error {
 type PullResponse struct {
 	currentBranch string
 	upstream      string
+	result        string
 	output        string
 	title         lipgloss.Style
 	error         error
@@ -52,16 +60,18 @@ func (r PullResponse) String() string {
 	}
 
 	return fmt.Sprintf(
-		"%s [%s <- %s] %s",
+		"%s [%s <- %s] %s %s",
 		r.title.Render(),
 		r.currentBranch,
 		r.upstream,
+		r.result,
 		r.output,
 	)
 }
 
-func pullProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
+func pullProjectRepos(project domain.Project, opts domain.PullOptions, deps types.RuntimeCLI) []error {
 	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	deps.Git = deps.Git.Batch()
 
 	errList := make([]error, 0)
 	maxLen := cli.GetMaxLen(project)
@@ -71,7 +81,7 @@ func pullProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			resp := pullRepo(project, repo, deps)
+			resp := pullRepo(project, repo, opts, deps)
 			resp.title.Width(maxLen)
 			fmt.Println(resp)
 			if resp.error != nil {
@@ -86,13 +96,18 @@ func pullProjectRepos(project domain.Project, deps types.RuntimeCLI) []error {
 
 	for _, subProject := range project.SubProjects {
 		fmt.Println()
-		errs := pullProjectRepos(subProject, deps)
+		errs := pullProjectRepos(subProject, opts, deps)
 		errList = append(errList, errs...)
 	}
 	return errList
 }
 
-func pullRepo(project domain.Project, repo domain.Repository, deps types.RuntimeCLI) PullResponse {
+func pullRepo(
+	project domain.Project,
+	repo domain.Repository,
+	opts domain.PullOptions,
+	deps types.RuntimeCLI,
+) PullResponse {
 	resp := PullResponse{
 		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
 		errorStyle: deps.Theme.Error,
@@ -104,30 +119,71 @@ func pullRepo(project domain.Project, repo domain.Repository, deps types.Runtime
 		return resp
 	}
 
+	// Skip repositories mid-operation, pulling would interfere with it.
+	operation, err := git.InProgress(repo.AbsPath)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	if operation != git.OperationNone {
+		resp.error = types.NewWarning("%s in progress, skipped", operation)
+		return resp
+	}
+
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Pull)
+	defer cancel()
+
 	gitRepo, err := deps.Git.Open(repo.AbsPath)
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
 	}
 
-	resp.currentBranch, err = gitRepo.CurrentBranch()
+	resp.currentBranch, err = gitRepo.CurrentBranch(ctx)
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
 	}
 
-	upstream, err := gitRepo.GetUpstream(resp.currentBranch)
+	resp.upstream, err = gitRepo.Upstream(ctx, resp.currentBranch)
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
 	}
-	resp.upstream = upstream.Short()
 
-	resp.output, err = deps.Git.Pull(repo.AbsPath)
+	pullOpts := repo.PullOptions.Merge(&opts)
+	result, err := deps.Git.Pull(ctx, repo.AbsPath, git.PullOptions{
+		Strategy:  git.PullStrategy(pullOpts.Strategy),
+		Autostash: pullOpts.Autostash,
+	})
 	if err != nil {
 		resp.error = cli.RepoError(err, repo)
 		return resp
 	}
+	if result.StashConflict {
+		err := fmt.Errorf("%s applied, restoring autostash conflicted: local changes kept in stash", result.Strategy)
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.result = renderResult(result, deps)
+	resp.output = result.Output
+	if repo.Submodules == domain.SubmodulesRecursive {
+		output, err := deps.Git.UpdateSubmodules(ctx, repo.AbsPath)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		resp.output = strings.TrimSpace(resp.output + "\n" + output)
+	}
 	resp.output = deps.Theme.GitOutput.Render(resp.output)
 	return resp
 }
+
+// renderResult returns the applied strategy, and whether a stash was restored.
+func renderResult(result git.PullResult, deps types.RuntimeCLI) string {
+	label := string(result.Strategy)
+	if result.Stashed {
+		label += ", stash restored"
+	}
+	return deps.Theme.Provider.Render("(" + label + ")")
+}
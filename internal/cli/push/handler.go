@@ -0,0 +1,233 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+// Options are the push command options.
+type Options struct {
+	// DryRun reports what would be pushed, without pushing.
+	DryRun bool
+	// SetUpstream pushes branches without upstream, or whose upstream is
+	// gone, to a remote branch of the same name, and sets it as upstream.
+	SetUpstream bool
+}
+
+// ExecPush pushes project repositories, or a specific repo, whose current
+// branch is ahead of upstream. Remotes are fetched first, and repositories
+// behind or diverged from upstream are skipped.
+//
+// Args: (optional)
+//   - project name
+//   - repo
+func ExecPush(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, repo, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	if repo != nil {
+		// Push a single repository.
+		resp := pushRepo(project, *repo, opts, deps)
+		fmt.Println(resp)
+		return resp.error
+	}
+
+	// Push all project's repositories.
+	errs := pushProjectRepos(project, opts, deps)
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type PushResponse struct {
+	currentBranch string
+	upstream      string
+	output        string
+	title         lipgloss.Style
+	error         error
+	errorStyle    lipgloss.Style
+}
+
+func (r PushResponse) String() string {
+	if r.error != nil {
+		return fmt.Sprintf("%s %s", r.title, r.errorStyle.Render(r.error.Error()))
+	}
+
+	return fmt.Sprintf(
+		"%s [%s -> %s] %s",
+		r.title.Render(),
+		r.currentBranch,
+		r.upstream,
+		r.output,
+	)
+}
+
+func pushProjectRepos(project domain.Project, opts Options, deps types.RuntimeCLI) []error {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	deps.Git = deps.Git.Batch()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+	)
+	for idx, repo := range project.Repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := pushRepo(project, repo, opts, deps)
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			if resp.error != nil {
+				mu.Lock()
+				errList = append(errList, resp.error)
+				mu.Unlock()
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		errs := pushProjectRepos(subProject, opts, deps)
+		errList = append(errList, errs...)
+	}
+	return errList
+}
+
+func pushRepo(
+	project domain.Project,
+	repo domain.Repository,
+	opts Options,
+	deps types.RuntimeCLI,
+) PushResponse {
+	resp := PushResponse{
+		title:      cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+
+	// Fetch first, so that safety checks compare with the remote state.
+	fetchCtx, cancelFetch := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancelFetch()
+	if _, err := deps.Git.Fetch(fetchCtx, repo.AbsPath); err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Push)
+	defer cancel()
+
+	status, err := deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.currentBranch = status.Branch
+	resp.upstream = status.Upstream
+
+	// Skip repositories that can't be pushed safely.
+	icons := deps.Settings.Icons
+	switch {
+	case status.Detached:
+		resp.error = types.NewWarning("detached HEAD, skipped")
+		return resp
+	case status.Operation != git.OperationNone:
+		resp.error = types.NewWarning("%s in progress, skipped", status.Operation)
+		return resp
+	case status.Upstream == "" && !opts.SetUpstream:
+		resp.error = types.NewWarning("%s has no upstream, skipped", status.Branch)
+		return resp
+	case status.Gone && !opts.SetUpstream:
+		resp.error = types.NewWarning("upstream %s is gone, skipped", status.Upstream)
+		return resp
+	case status.Ahead > 0 && status.Behind > 0:
+		resp.error = types.NewWarning(
+			"diverged from %s (%s%d %s%d), skipped",
+			status.Upstream, icons.Ahead, status.Ahead, icons.Behind, status.Behind,
+		)
+		return resp
+	case status.Behind > 0:
+		resp.error = types.NewWarning(
+			"behind %s by %d, skipped", status.Upstream, status.Behind,
+		)
+		return resp
+	}
+
+	pushOpts := git.PushOptions{}
+	summary := fmt.Sprintf("%s%d", icons.Ahead, status.Ahead)
+	if status.Upstream == "" || status.Gone {
+		remote, err := pushRemote(ctx, repo, deps)
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		pushOpts = git.PushOptions{
+			SetUpstream: true,
+			Remote:      remote,
+			Branch:      status.Branch,
+		}
+		resp.upstream = remote + "/" + status.Branch
+		summary = "new branch"
+	} else if status.Ahead == 0 {
+		resp.output = deps.Theme.GitOutput.Render("up to date")
+		return resp
+	}
+
+	if opts.DryRun {
+		resp.output = deps.Theme.Provider.Render("(" + summary + ", dry-run)")
+		return resp
+	}
+	output, err := deps.Git.Push(ctx, repo.AbsPath, pushOpts)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.output = fmt.Sprintf(
+		"%s %s",
+		deps.Theme.Provider.Render("("+summary+")"),
+		deps.Theme.GitOutput.Render(output),
+	)
+	return resp
+}
+
+// pushRemote returns the remote to push new branches to, origin if it
+// exists, otherwise the first remote.
+func pushRemote(ctx context.Context, repo domain.Repository, deps types.RuntimeCLI) (string, error) {
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
+	if err != nil {
+		return "", err
+	}
+	remotes, err := gitRepo.Remotes(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(remotes) == 0 {
+		return "", fmt.Errorf("no remote to push to")
+	}
+	if slices.Contains(remotes, domain.RemoteOrigin) {
+		return domain.RemoteOrigin, nil
+	}
+	return remotes[0], nil
+}
@@ -0,0 +1,265 @@
+package relocate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/karrick/godirwalk"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+	"github.com/rafi/gits/pkg/providers"
+)
+
+var resultHeaders = []string{"REPO", "FROM", "TO", "RESULT"}
+
+// Options are the relocate command options.
+type Options struct {
+	// DryRun lists clones to move, without moving them.
+	DryRun bool
+	// From are extra directories to scan for clones, e.g. a previous project
+	// path.
+	From []string
+}
+
+// move is a clone found at a different path than its configured repository.
+type move struct {
+	name string
+	repo domain.Repository
+	from string
+	to   string
+	skip string
+}
+
+// ExecRelocate moves clones whose remote matches a configured repository, but
+// sit at a different path, to the repository's configured path.
+//
+// Args: (optional)
+//   - project name
+func ExecRelocate(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	roots := []string{}
+	if project.AbsPath != "" {
+		roots = append(roots, project.AbsPath)
+	}
+	for _, dir := range opts.From {
+		expanded, err := homedir.Expand(dir)
+		if err != nil {
+			return fmt.Errorf("unable to expand path: %w", err)
+		}
+		abs, err := filepath.Abs(expanded)
+		if err != nil {
+			return fmt.Errorf("unable to resolve path: %w", err)
+		}
+		roots = append(roots, abs)
+	}
+	if len(roots) == 0 {
+		return fmt.Errorf(
+			"project %q has no path, use --from to choose directories to scan",
+			project.Name,
+		)
+	}
+
+	moves, errs := findMoves(project, roots, deps)
+
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	if len(moves) == 0 {
+		fmt.Println("  " + deps.Theme.Provider.Render("no clones to relocate"))
+	} else {
+		rows := [][]string{}
+		for _, m := range moves {
+			result := deps.Theme.Provider.Render("would move")
+			switch {
+			case m.skip != "":
+				result = deps.Theme.Provider.Render("skipped, " + m.skip)
+			case !opts.DryRun:
+				if err := moveClone(m.from, m.to, deps); err != nil {
+					errs = append(errs, cli.RepoError(err, m.repo))
+					result = deps.Theme.Error.Render("failed")
+				} else {
+					result = deps.Theme.GitOutput.Render("moved")
+				}
+			}
+			rows = append(rows, []string{
+				deps.Theme.RepoTitle.Render(m.name),
+				cli.Path(m.from, deps.HomeDir),
+				cli.Path(m.to, deps.HomeDir),
+				result,
+			})
+		}
+		fmt.Println(cli.Table(resultHeaders, rows, deps.Theme))
+	}
+
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// findMoves matches unknown clones found in roots with repositories that are
+// not cloned at their configured path, ordered by namespaced repository name.
+// Ambiguous matches are returned as skipped moves.
+func findMoves(project domain.Project, roots []string, deps types.RuntimeCLI) ([]move, []error) {
+	// Index missing repositories by remote, and collect all known paths.
+	known := map[string]bool{}
+	missing := map[string][]string{}
+	names := project.ListReposWithNamespace()
+	for _, name := range names {
+		repo, found := project.GetRepo(name, "")
+		if !found {
+			continue
+		}
+		known[repo.AbsPath] = true
+		if repo.State == domain.RepoStateNoLocal && repo.Src != "" {
+			key := remoteKey(repo.Src)
+			missing[key] = append(missing[key], name)
+		}
+	}
+
+	clones, err := findClones(deps.Context, roots, known, deps.Git, deps.Settings.Timeouts.Status)
+	if err != nil {
+		return nil, []error{err}
+	}
+	paths := make([]string, 0, len(clones))
+	for path := range clones {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Match clones by remote, refusing ambiguous matches either way.
+	moves := []move{}
+	matches := map[string][]string{}
+	for _, path := range paths {
+		candidates := missing[remoteKey(clones[path])]
+		switch len(candidates) {
+		case 0:
+		case 1:
+			matches[candidates[0]] = append(matches[candidates[0]], path)
+		default:
+			moves = append(moves, move{
+				name: strings.Join(candidates, ", "),
+				from: path,
+				skip: fmt.Sprintf("remote matches %d repositories", len(candidates)),
+			})
+		}
+	}
+	for _, name := range names {
+		repo, _ := project.GetRepo(name, "")
+		found := matches[name]
+		for _, path := range found {
+			m := move{name: name, repo: repo, from: path, to: repo.AbsPath}
+			if len(found) > 1 {
+				m.skip = fmt.Sprintf("%d clones found", len(found))
+			}
+			moves = append(moves, m)
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].name < moves[j].name
+	})
+	return moves, nil
+}
+
+// findClones scans roots for repositories that are not at known paths, and
+// returns their remote URLs by path. Each remote is read within timeout.
+func findClones(
+	ctx context.Context,
+	roots []string,
+	known map[string]bool,
+	gitClient git.Git,
+	timeout time.Duration,
+) (map[string]string, error) {
+	clones := map[string]string{}
+	for _, root := range roots {
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+		err := godirwalk.Walk(root, &godirwalk.Options{
+			Unsorted:            false,
+			FollowSymbolicLinks: false,
+			Callback: func(path string, de *godirwalk.Dirent) error {
+				if !de.IsDir() || !gitClient.IsRepo(path) {
+					return nil
+				}
+				if _, seen := clones[path]; !seen && !known[path] {
+					repoCtx, cancel := cli.WithTimeout(ctx, timeout)
+					remote, err := gitClient.Remote(repoCtx, path)
+					cancel()
+					if err == nil && remote != "" {
+						clones[path] = remote
+					}
+				}
+				return filepath.SkipDir
+			},
+			ErrorCallback: func(path string, err error) godirwalk.ErrorAction {
+				return godirwalk.SkipNode
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan %s: %w", root, err)
+		}
+	}
+	return clones, nil
+}
+
+// remoteKey normalizes a remote URL for comparison, so that the SSH and HTTPS
+// URLs of a repository, with or without .git suffix or port, are equal.
+func remoteKey(remote string) string {
+	remote = strings.TrimSpace(remote)
+	local := strings.TrimPrefix(remote, "file://")
+	if filepath.IsAbs(local) || strings.HasPrefix(local, "~") {
+		if expanded, err := homedir.Expand(local); err == nil {
+			local = expanded
+		}
+		return strings.TrimSuffix(filepath.Clean(local), ".git")
+	}
+	if webURL, err := providers.WebURL(remote); err == nil {
+		_, hostPath, _ := strings.Cut(webURL, "://")
+		host, repoPath, _ := strings.Cut(hostPath, "/")
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		return strings.ToLower(host + "/" + repoPath)
+	}
+	return remote
+}
+
+// moveClone moves a clone directory, creating missing parent directories,
+// and repairs the links of its worktrees.
+func moveClone(from, to string, deps types.RuntimeCLI) error {
+	if _, err := os.Lstat(to); err == nil {
+		return fmt.Errorf("destination %s already exists", to)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return fmt.Errorf("unable to create directory: %w", err)
+	}
+	if err := os.Rename(from, to); err != nil {
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("unable to move clone across filesystems, move %s manually", from)
+		}
+		return fmt.Errorf("unable to move clone: %w", err)
+	}
+
+	// Linked worktrees and their main clone refer to each other by path.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	if _, err := deps.Git.RepairWorktrees(ctx, to); err != nil {
+		return fmt.Errorf("moved to %s, but %w", to, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package relocate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git/gittest"
+)
+
+func TestRemoteKey(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{remote: "git@github.com:Acme/API.git", want: "github.com/acme/api"},
+		{remote: "https://github.com/acme/api", want: "github.com/acme/api"},
+		{remote: "ssh://git@github.com:2222/acme/api.git", want: "github.com/acme/api"},
+		{remote: "https://git.example.com:8443/acme/api.git", want: "git.example.com/acme/api"},
+		{remote: " https://github.com/acme/api.git\n", want: "github.com/acme/api"},
+		{remote: "/srv/git/api.git", want: "/srv/git/api"},
+		{remote: "file:///srv/git/../git/api.git", want: "/srv/git/api"},
+		{remote: "~/git/api.git", want: filepath.Join(home, "git/api")},
+		{remote: "unknown", want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			if got := remoteKey(tt.remote); got != tt.want {
+				t.Errorf("remoteKey(%q) = %q, want %q", tt.remote, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMoves(t *testing.T) {
+	root := t.TempDir()
+	code := filepath.Join(root, "code")
+	old := filepath.Join(root, "old")
+
+	// Clone directories by path, and their origin URL.
+	clones := map[string]string{
+		filepath.Join(code, "cli"):  "git@github.com:acme/cli.git",
+		filepath.Join(old, "api"):   "https://github.com/acme/api.git",
+		filepath.Join(old, "dup"):   "https://github.com/acme/dup",
+		filepath.Join(old, "other"): "git@github.com:acme/other.git",
+		filepath.Join(old, "web1"):  "git@github.com:acme/web.git",
+		filepath.Join(old, "web2"):  "https://github.com/acme/web",
+	}
+	fakeRepos := map[string]*gittest.Repo{}
+	for path, url := range clones {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		fakeRepos[path] = &gittest.Repo{Remotes: map[string]string{"origin": url}}
+	}
+
+	repo := func(name, src string, state domain.RepoState) domain.Repository {
+		return domain.Repository{
+			Name:    name,
+			Src:     src,
+			AbsPath: filepath.Join(code, name),
+			State:   state,
+		}
+	}
+	api := repo("api", "git@github.com:acme/api.git", domain.RepoStateNoLocal)
+	web := repo("web", "git@github.com:acme/web.git", domain.RepoStateNoLocal)
+	project := domain.Project{
+		Name:    "acme",
+		AbsPath: code,
+		Repos: []domain.Repository{
+			api,
+			web,
+			repo("cli", "git@github.com:acme/cli.git", domain.RepoStateOK),
+			repo("dup1", "git@github.com:acme/dup.git", domain.RepoStateNoLocal),
+			repo("dup2", "git@github.com:acme/dup.git", domain.RepoStateNoLocal),
+		},
+	}
+
+	deps := types.RuntimeCLI{}
+	deps.Context = context.Background()
+	deps.Git = gittest.New(fakeRepos)
+
+	got, errs := findMoves(project, []string{code, old}, deps)
+	if len(errs) > 0 {
+		t.Fatalf("findMoves() errors = %v", errs)
+	}
+	want := []move{
+		{name: "api", repo: api, from: filepath.Join(old, "api"), to: api.AbsPath},
+		{
+			name: "dup1, dup2",
+			from: filepath.Join(old, "dup"),
+			skip: "remote matches 2 repositories",
+		},
+		{
+			name: "web",
+			repo: web,
+			from: filepath.Join(old, "web1"),
+			to:   web.AbsPath,
+			skip: "2 clones found",
+		},
+		{
+			name: "web",
+			repo: web,
+			from: filepath.Join(old, "web2"),
+			to:   web.AbsPath,
+			skip: "2 clones found",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findMoves() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package rm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+)
+
+// Options are the rm command options.
+type Options struct {
+	// Force removes clones even if local work would be lost.
+	Force bool
+	// Archive is a directory to save a tar.gz of the clone in, before removal.
+	Archive string
+}
+
+// ExecRemove removes the local clone of a repository, refusing if any
+// uncommitted changes, stashes, unpushed commits or linked worktrees would
+// be lost. Remotes are fetched first, so deleted remote branches don't count
+// as pushed.
+//
+// Args: (optional)
+//   - project name
+//   - repo
+func ExecRemove(opts Options, args []string, deps types.RuntimeCLI) error {
+	project, repo, err := cli.ParseArgs(args, false, deps)
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return errors.New("missing repo name")
+	}
+	title := cli.RepoTitle(*repo, project.AbsPath, deps.HomeDir, deps.Theme)
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		return cli.AbortOnRepoState(*repo, deps.Theme.Error)
+	}
+	if err := checkPath(repo.AbsPath, project, deps); err != nil {
+		return err
+	}
+
+	// Prune deleted remote branches, offline clones use the last fetch.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancel()
+	if _, err := deps.Git.Fetch(ctx, repo.AbsPath); err != nil {
+		fmt.Println(title.Render(), deps.Theme.Provider.Render(
+			"unable to fetch, checking unpushed commits against the last fetch",
+		))
+	}
+
+	losses, err := findLosses(*repo, deps)
+	if err != nil {
+		return fmt.Errorf("%s: %w", repo.GetName(), err)
+	}
+	if len(losses) > 0 {
+		fmt.Println(title.Render(), deps.Theme.Error.Render("would lose:"))
+		for _, loss := range losses {
+			fmt.Println("  - " + loss)
+		}
+		if !opts.Force {
+			return fmt.Errorf("refusing to remove %s, use --force to remove anyway", repo.GetName())
+		}
+	}
+
+	summary := []string{}
+	if opts.Archive != "" {
+		file, err := archiveRepo(*repo, opts.Archive)
+		if err != nil {
+			return fmt.Errorf("%s: %w", repo.GetName(), err)
+		}
+		summary = append(summary, "archived to "+cli.Path(file, deps.HomeDir))
+	}
+	if err := os.RemoveAll(repo.AbsPath); err != nil {
+		return fmt.Errorf("%s: unable to remove clone: %w", repo.GetName(), err)
+	}
+
+	result := "removed " + cli.Path(repo.AbsPath, deps.HomeDir)
+	if len(summary) > 0 {
+		result += " " + deps.Theme.Provider.Render("("+strings.Join(summary, ", ")+")")
+	}
+	fmt.Println(title.Render(), deps.Theme.GitOutput.Render(result))
+	return nil
+}
+
+// checkPath refuses to remove paths that contain more than a clone.
+func checkPath(path string, project domain.Project, deps types.RuntimeCLI) error {
+	clean := filepath.Clean(path)
+	if !filepath.IsAbs(clean) || clean == filepath.Dir(clean) ||
+		clean == filepath.Clean(deps.HomeDir) || clean == filepath.Clean(project.AbsPath) {
+		return fmt.Errorf("refusing to remove %s", path)
+	}
+	return nil
+}
+
+// findLosses returns local work that exists only in the clone.
+func findLosses(repo domain.Repository, deps types.RuntimeCLI) ([]string, error) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+
+	status, err := deps.Git.Status(ctx, repo.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	losses := []string{}
+	if changes := status.Staged + status.Unstaged + status.Conflicted; changes > 0 {
+		losses = append(losses, plural(changes, "uncommitted change"))
+	}
+	if status.Untracked > 0 {
+		losses = append(losses, plural(status.Untracked, "untracked file"))
+	}
+	if status.Stashes > 0 {
+		losses = append(losses, plural(status.Stashes, "stash"))
+	}
+	if status.Operation != "" {
+		losses = append(losses, fmt.Sprintf("%s in progress", status.Operation))
+	}
+
+	branches, err := deps.Git.UnpushedBranches(ctx, repo.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range branches {
+		commits := plural(branch.Commits, "commit")
+		switch {
+		case branch.Detached:
+			losses = append(losses, commits+" on detached HEAD")
+		case branch.Upstream == "":
+			losses = append(losses, fmt.Sprintf("local-only branch %s (%s)", branch.Name, commits))
+		default:
+			losses = append(losses, fmt.Sprintf("%s unpushed on %s", commits, branch.Name))
+		}
+	}
+
+	// Linked worktrees break once their main clone is removed.
+	worktrees, err := deps.Git.Worktrees(ctx, repo.AbsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, worktree := range worktrees {
+		if !worktree.Main {
+			losses = append(losses, "linked worktree "+cli.Path(worktree.Path, deps.HomeDir))
+		}
+	}
+	return losses, nil
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	if strings.HasSuffix(noun, "sh") {
+		return fmt.Sprintf("%d %ses", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// archiveName returns a timestamped archive file name of a repository.
+func archiveName(repo domain.Repository, now time.Time) string {
+	name := strings.ReplaceAll(repo.GetNameWithNamespace(), "/", "-")
+	return fmt.Sprintf("%s-%s.tar.gz", name, now.Format("20060102-150405"))
+}
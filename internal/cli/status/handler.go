@@ -1,43 +1,74 @@
 package status
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cli"
 	"github.com/rafi/gits/internal/types"
 )
 
+// Options are the status command options.
+type Options struct {
+	// Cached renders the last saved snapshots, and refreshes them in the
+	// background.
+	Cached bool
+	// Refresh computes and saves snapshots without rendering them.
+	Refresh bool
+}
+
 // ExecStatus displays an icon based status of all repositories.
 // Runs 'git' directly due to https://github.com/go-git/go-git/issues/181
 //
 // Args: (optional)
 //   - project name
 //   - repo or sub-project name
-func ExecStatus(args []string, deps types.RuntimeCLI) error {
+func ExecStatus(opts Options, args []string, deps types.RuntimeCLI) error {
+	if opts.Refresh {
+		return refreshSnapshots(args, deps)
+	}
+
+	// Loading a project may alter arguments, keep the originals for refresh.
+	origArgs := append([]string{}, args...)
+
 	project, repo, err := cli.ParseArgs(args, true, deps)
 	if err != nil {
 		return err
 	}
 
+	if opts.Cached {
+		if len(origArgs) == 0 {
+			origArgs = []string{project.Name}
+		}
+		defer func() {
+			if err := refreshInBackground(origArgs, deps); err != nil {
+				log.Warnf("unable to refresh status in background: %s", err)
+			}
+		}()
+	}
+
 	if repo != nil {
 		// Display status for a single repository.
 		title := cli.RepoTitle(*repo, project.AbsPath, deps.HomeDir, deps.Theme).Align(lipgloss.Right)
 		fmt.Printf("%s ", title)
-		return statusRepo(*repo, deps)
+		return statusRepo(*repo, opts, deps)
 	}
 
 	// Display status for all project's repositories.
-	errs := statusProject(project, deps)
+	errs := statusProject(project, opts, deps)
 	if len(errs) > 0 {
 		return cli.RenderErrors(errs, true)
 	}
 	return nil
 }
 
-func statusProject(project domain.Project, deps types.RuntimeCLI) []error {
+func statusProject(project domain.Project, opts Options, deps types.RuntimeCLI) []error {
 	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
 	maxLen := cli.GetMaxLen(project)
 
@@ -49,20 +80,20 @@ func statusProject(project domain.Project, deps types.RuntimeCLI) []error {
 			Render()
 
 		fmt.Printf("%s ", repoTitle)
-		err := statusRepo(repo, deps)
+		err := statusRepo(repo, opts, deps)
 		if err != nil {
 			errList = append(errList, err)
 		}
 	}
 	for _, subProject := range project.SubProjects {
 		fmt.Println()
-		errs := statusProject(subProject, deps)
+		errs := statusProject(subProject, opts, deps)
 		errList = append(errList, errs...)
 	}
 	return errList
 }
 
-func statusRepo(repo domain.Repository, deps types.RuntimeCLI) error {
+func statusRepo(repo domain.Repository, opts Options, deps types.RuntimeCLI) error {
 	defer fmt.Println()
 
 	// Abort if repository is not cloned or has errors.
@@ -71,64 +102,170 @@ func statusRepo(repo domain.Repository, deps types.RuntimeCLI) error {
 		return cli.AbortOnRepoState(repo, errStyle)
 	}
 
-	version, err := deps.Git.Describe(repo.AbsPath)
+	var (
+		status domain.RepoStatus
+		err    error
+	)
+	if opts.Cached {
+		found, err := deps.Cache.GetStatus(repo.AbsPath, &status)
+		if err != nil {
+			return cli.RepoError(err, repo)
+		}
+		if !found {
+			fmt.Print(deps.Theme.Error.PaddingLeft(8).Render("no snapshot"))
+			return nil
+		}
+	} else {
+		status, err = computeStatus(repo, deps)
+		if err != nil {
+			return cli.RepoError(err, repo)
+		}
+		if err := deps.Cache.SaveStatus(repo.AbsPath, status); err != nil {
+			log.Debugf("unable to save status snapshot: %s", err)
+		}
+	}
+
+	fmt.Print(renderStatus(status, deps))
+	if opts.Cached {
+		age := time.Since(status.Timestamp).Round(time.Second)
+		fmt.Print(deps.Theme.Provider.Render(fmt.Sprintf(" (%s ago)", age)))
+	}
+	return nil
+}
+
+// computeStatus runs git to compute a repository status snapshot.
+func computeStatus(repo domain.Repository, deps types.RuntimeCLI) (domain.RepoStatus, error) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+
+	gitStatus, err := deps.Git.Status(ctx, repo.AbsPath)
 	if err != nil {
-		version = ""
+		return domain.RepoStatus{}, err
+	}
+
+	status := domain.RepoStatus{
+		Branch:     gitStatus.Branch,
+		Upstream:   gitStatus.Upstream,
+		Detached:   gitStatus.Detached,
+		Ahead:      gitStatus.Ahead,
+		Behind:     gitStatus.Behind,
+		Staged:     gitStatus.Staged,
+		Modified:   gitStatus.Unstaged,
+		Untracked:  gitStatus.Untracked,
+		Conflicted: gitStatus.Conflicted,
+		Stashes:    gitStatus.Stashes,
+		Operation:  string(gitStatus.Operation),
+		Timestamp:  time.Now(),
+	}
+
+	// Without a tracking branch, compare with the same branch name at origin.
+	if status.Upstream == "" && !status.Detached && status.Branch != "" {
+		status.Ahead, status.Behind, err = diffOrigin(ctx, repo, status.Branch, deps)
+		if err != nil {
+			log.Debug(err)
+			status.DiffFailed = true
+		}
 	}
 
-	var count int
-	modified := ""
-	if count, err = deps.Git.Modified(repo.AbsPath); err != nil {
-		return cli.RepoError(err, repo)
-	} else if count > 0 {
-		modified = fmt.Sprintf("≠%d", count)
+	if repo.Submodules == domain.SubmodulesRecursive {
+		status.Submodules, err = outOfSyncSubmodules(ctx, repo, deps)
+		if err != nil {
+			log.Debug(err)
+		}
 	}
-	untracked := ""
-	if count, err = deps.Git.Untracked(repo.AbsPath); err != nil {
-		return cli.RepoError(err, repo)
-	} else if count > 0 {
-		untracked = fmt.Sprintf("?%d", count)
+
+	status.Version, err = deps.Git.Describe(ctx, repo.AbsPath)
+	if err != nil {
+		status.Version = ""
 	}
 
-	branch, err := deps.Git.CurrentBranch(repo.AbsPath)
+	status.Position, err = deps.Git.CurrentPosition(ctx, repo.AbsPath)
 	if err != nil {
-		fmt.Println(err)
+		status.Position = deps.Settings.Icons.NA
 	}
-	upstream, err := deps.Git.UpstreamBranch(repo.AbsPath)
+	return status, nil
+}
+
+// diffOrigin returns ahead/behind counts of branch compared with the same
+// branch name at origin.
+func diffOrigin(ctx context.Context, repo domain.Repository, branch string, deps types.RuntimeCLI) (int, int, error) {
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
 	if err != nil {
-		upstream = "ERROR"
-		fmt.Println(err)
+		return 0, 0, err
 	}
-	if upstream == "" {
-		upstream = fmt.Sprintf("origin/%v", branch)
+	if !gitRepo.IsRemoteBranch(ctx, "origin", branch) {
+		return 0, 0, fmt.Errorf("no origin/%s to compare with", branch)
 	}
+	return deps.Git.Diff(ctx, repo.AbsPath, branch, "origin/"+branch)
+}
 
-	diff := ""
-	ahead, behind, err := deps.Git.Diff(repo.AbsPath, branch, upstream)
+// outOfSyncSubmodules counts submodules not checked out at their recorded
+// commit.
+func outOfSyncSubmodules(ctx context.Context, repo domain.Repository, deps types.RuntimeCLI) (int, error) {
+	submodules, err := deps.Git.Submodules(ctx, repo.AbsPath)
 	if err != nil {
-		diff = "-"
+		return 0, err
 	}
-	if ahead == 0 && behind == 0 {
-		diff = "✓"
+	outOfSync := 0
+	for _, submodule := range submodules {
+		if !submodule.InSync() {
+			outOfSync++
+		}
 	}
-	if ahead > 0 {
-		diff = fmt.Sprintf("▲%d", ahead)
+	return outOfSync, nil
+}
+
+// renderStatus returns a one-line icon based status.
+func renderStatus(status domain.RepoStatus, deps types.RuntimeCLI) string {
+	icons := deps.Settings.Icons
+	theme := deps.Theme
+
+	diff := ""
+	switch {
+	case status.DiffFailed:
+		diff = icons.DiffError
+	case status.Ahead == 0 && status.Behind == 0:
+		diff = icons.DiffClean
 	}
-	if behind > 0 {
-		diff = fmt.Sprintf("%s▼%d", diff, behind)
+	if status.Ahead > 0 {
+		diff = fmt.Sprintf("%s%d", icons.Ahead, status.Ahead)
+	}
+	if status.Behind > 0 {
+		diff = fmt.Sprintf("%s%s%d", diff, icons.Behind, status.Behind)
 	}
 
-	currentRef, err := deps.Git.CurrentPosition(repo.AbsPath)
-	if err != nil {
-		currentRef = "N/A"
+	parts := []string{
+		theme.Staged.Render(count(icons.Staged, status.Staged)),
+		theme.Modified.Render(count(icons.Modified, status.Modified)),
+		theme.Untracked.Render(count(icons.Untracked, status.Untracked)),
+		theme.Diff.Render(diff),
 	}
 
-	fmt.Printf("%s %s %s %s %s",
-		deps.Theme.Modified.Render(modified),
-		deps.Theme.Untracked.Render(untracked),
-		deps.Theme.Diff.Render(diff),
-		version,
-		currentRef,
-	)
-	return nil
+	// Conditions requiring attention are only displayed when present.
+	if status.Operation != "" {
+		parts = append(parts, theme.InProgress.Render(icons.InProgress+status.Operation))
+	}
+	if status.Conflicted > 0 {
+		parts = append(parts, theme.Conflicted.Render(count(icons.Conflicted, status.Conflicted)))
+	}
+	if status.Detached {
+		parts = append(parts, theme.Detached.Render(icons.Detached+"detached"))
+	}
+	if status.Stashes > 0 {
+		parts = append(parts, theme.Stashed.Render(count(icons.Stashed, status.Stashes)))
+	}
+	if status.Submodules > 0 {
+		parts = append(parts, theme.Submodules.Render(count(icons.Submodules, status.Submodules)))
+	}
+
+	parts = append(parts, status.Version, status.Position)
+	return strings.Join(parts, " ")
+}
+
+// count returns an icon followed by n, or empty if n is zero.
+func count(icon string, n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", icon, n)
 }
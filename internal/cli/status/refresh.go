@@ -0,0 +1,107 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/loader"
+	"github.com/rafi/gits/internal/types"
+)
+
+// refreshInBackground spawns a detached gits process that refreshes the
+// status snapshots of the given project arguments.
+func refreshInBackground(args []string, deps types.RuntimeCLI) error {
+	bin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find executable: %w", err)
+	}
+	cmdArgs := []string{"--color=never"}
+	if deps.ConfigPath != "" {
+		cmdArgs = append(cmdArgs, "--config", deps.ConfigPath)
+	}
+	cmdArgs = append(cmdArgs, "status", "--refresh")
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.CommandContext(context.Background(), bin, cmdArgs...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	log.Debugf("Refreshing status snapshots in background (pid %d)", cmd.Process.Pid)
+	return cmd.Process.Release()
+}
+
+// refreshSnapshots computes and saves status snapshots of a project, or of
+// all projects when no arguments are provided.
+func refreshSnapshots(args []string, deps types.RuntimeCLI) error {
+	var projects []domain.Project
+	if len(args) == 0 {
+		all, err := loader.GetProjects(nil, deps.Runtime)
+		if err != nil {
+			return err
+		}
+		for _, project := range all {
+			projects = append(projects, project)
+		}
+	} else {
+		project, repo, err := cli.ParseArgs(args, true, deps)
+		if err != nil {
+			return err
+		}
+		if repo != nil {
+			project.Repos = []domain.Repository{*repo}
+			project.SubProjects = nil
+		}
+		projects = append(projects, project)
+	}
+
+	errList := []error{}
+	for _, project := range projects {
+		errList = append(errList, refreshProject(project, deps)...)
+	}
+	return errors.Join(errList...)
+}
+
+// refreshProject recursively saves status snapshots of project repositories.
+func refreshProject(project domain.Project, deps types.RuntimeCLI) []error {
+	deps.Git = deps.Git.Batch()
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+	)
+	for idx, repo := range project.Repos {
+		if repo.State != domain.RepoStateOK {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := computeStatus(repo, deps)
+			if err == nil {
+				err = deps.Cache.SaveStatus(repo.AbsPath, status)
+			}
+			if err != nil {
+				mu.Lock()
+				errList = append(errList, cli.RepoError(err, repo))
+				mu.Unlock()
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		errList = append(errList, refreshProject(subProject, deps)...)
+	}
+	return errList
+}
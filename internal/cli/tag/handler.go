@@ -0,0 +1,255 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+var resultHeaders = []string{"REPO", "COMMIT", "RESULT"}
+
+// Options are the tag command options.
+type Options struct {
+	// Message of the annotated tag, defaults to "Release <version>".
+	Message string
+	// Sign creates GPG-signed tags.
+	Sign bool
+	// Push pushes tags to the upstream remote.
+	Push bool
+	// Check reports repositories missing the tag, without tagging.
+	Check bool
+}
+
+// result is the outcome of tagging a single repository.
+type result struct {
+	cli.RepoResult
+	commit   string
+	remote   string
+	selected bool
+	// tagged is true if the tag already points to HEAD.
+	tagged bool
+}
+
+// ExecTag creates a release tag in all cloned project repositories, only if
+// every repository is clean, on its default branch and in sync with
+// upstream.
+//
+// Args:
+//   - version
+//   - project name (optional)
+func ExecTag(opts Options, args []string, deps types.RuntimeCLI) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing tag version")
+	}
+	version := args[0]
+	project, _, err := cli.ParseArgs(args[1:], true, deps)
+	if err != nil {
+		return err
+	}
+	deps.Git = deps.Git.Batch()
+
+	if opts.Check {
+		return checkProject(project, version, deps)
+	}
+	if opts.Message == "" {
+		opts.Message = "Release " + version
+	}
+
+	results := eachRepo(project, deps, func(res *result) {
+		verifyRepo(res, version, deps)
+	})
+	total, notReady := 0, 0
+	for _, res := range results {
+		if res.selected {
+			total++
+		}
+		if res.Failed || res.Error != nil {
+			notReady++
+		}
+	}
+	if notReady > 0 {
+		errs := render(project, results, deps)
+		if len(errs) > 0 {
+			_ = cli.RenderErrors(errs, true)
+		}
+		return fmt.Errorf("%d of %d repositories not ready, nothing tagged", notReady, total)
+	}
+
+	results = eachResult(results, deps, func(res *result) {
+		tagRepo(res, version, opts, deps)
+	})
+	errs := render(project, results, deps)
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// checkProject reports repositories missing the tag, after fetching tags.
+func checkProject(project domain.Project, version string, deps types.RuntimeCLI) error {
+	results := eachRepo(project, deps, func(res *result) {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+		defer cancel()
+		if _, err := deps.Git.Fetch(ctx, res.Repo.AbsPath); err != nil {
+			res.Error = err
+			return
+		}
+		commit, err := deps.Git.TagCommit(ctx, res.Repo.AbsPath, version)
+		if err != nil {
+			res.Error = err
+			return
+		}
+		res.commit = commit
+		if commit == "" {
+			res.Failed, res.Status = true, "missing "+version
+			return
+		}
+		res.Status = "tagged"
+	})
+
+	errs := render(project, results, deps)
+	total, missing := 0, 0
+	for _, res := range results {
+		if res.selected {
+			total++
+		}
+		if res.Failed {
+			missing++
+		}
+	}
+	if len(errs) > 0 {
+		_ = cli.RenderErrors(errs, true)
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d of %d repositories missing tag %s", missing, total, version)
+	}
+	return nil
+}
+
+// verifyRepo reports a problem if repository is not clean, on its default
+// branch and in sync with upstream, or if the tag exists elsewhere.
+func verifyRepo(res *result, version string, deps types.RuntimeCLI) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Fetch)
+	defer cancel()
+	path := res.Repo.AbsPath
+
+	if _, err := deps.Git.Fetch(ctx, path); err != nil {
+		res.Error = err
+		return
+	}
+	status, err := deps.Git.Status(ctx, path)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	res.commit = status.Commit
+	defaultBranch, err := deps.Git.DefaultBranch(ctx, path, domain.RemoteOrigin)
+	if err != nil {
+		res.Error = err
+		return
+	}
+	tagCommit, err := deps.Git.TagCommit(ctx, path, version)
+	if err != nil {
+		res.Error = err
+		return
+	}
+
+	icons := deps.Settings.Icons
+	changes := status.Staged + status.Unstaged + status.Untracked + status.Conflicted
+	switch {
+	case tagCommit != "" && tagCommit != status.Commit:
+		res.Failed, res.Status = true, fmt.Sprintf("%s exists on %s", version, cli.ShortHash(tagCommit))
+	case status.Operation != git.OperationNone:
+		res.Failed, res.Status = true, fmt.Sprintf("%s in progress", status.Operation)
+	case status.Detached:
+		res.Failed, res.Status = true, "detached HEAD"
+	case status.Branch != defaultBranch:
+		res.Failed, res.Status = true, fmt.Sprintf("on %s, not %s", status.Branch, defaultBranch)
+	case changes > 0:
+		res.Failed, res.Status = true, "uncommitted changes"
+	case status.Upstream == "":
+		res.Failed, res.Status = true, "no upstream"
+	case status.Ahead > 0 || status.Behind > 0:
+		res.Failed, res.Status = true, fmt.Sprintf(
+			"out of sync (%s%d %s%d)", icons.Ahead, status.Ahead, icons.Behind, status.Behind,
+		)
+	default:
+		res.tagged = tagCommit != ""
+		res.remote, _, _ = strings.Cut(status.Upstream, "/")
+		res.Status = "ready"
+	}
+}
+
+// tagRepo creates the tag on HEAD, and pushes it if requested.
+func tagRepo(res *result, version string, opts Options, deps types.RuntimeCLI) {
+	path := res.Repo.AbsPath
+	summary := []string{"already tagged"}
+	if !res.tagged {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+		defer cancel()
+		if _, err := deps.Git.CreateTag(ctx, path, version, git.TagOptions{
+			Message: opts.Message,
+			Sign:    opts.Sign,
+		}); err != nil {
+			res.Error = err
+			return
+		}
+		summary = []string{"tagged"}
+	}
+	if opts.Push {
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Push)
+		defer cancel()
+		if _, err := deps.Git.PushTag(ctx, path, res.remote, version); err != nil {
+			res.Error = err
+			return
+		}
+		summary = append(summary, "pushed to "+res.remote)
+	}
+	res.Status = strings.Join(summary, ", ")
+}
+
+// eachRepo runs fn concurrently in cloned project repositories, results are
+// ordered by namespaced repository name.
+func eachRepo(project domain.Project, deps types.RuntimeCLI, fn func(*result)) []result {
+	results := []result{}
+	for _, repoResult := range cli.RepoResults(project) {
+		res := result{RepoResult: repoResult}
+		res.selected = res.CheckState()
+		results = append(results, res)
+	}
+	return eachResult(results, deps, fn)
+}
+
+// eachResult runs fn concurrently for selected results.
+func eachResult(results []result, deps types.RuntimeCLI, fn func(*result)) []result {
+	cli.ForEach(len(results), deps.Settings.WorkerCount, func(idx int) {
+		if results[idx].selected {
+			fn(&results[idx])
+		}
+	})
+	return results
+}
+
+// render prints a result table, and returns repository errors.
+func render(project domain.Project, results []result, deps types.RuntimeCLI) []error {
+	rows := make([]cli.RepoResult, 0, len(results))
+	for _, res := range results {
+		row := res.RepoResult
+		row.Details = []string{cli.ShortHash(res.commit)}
+		rows = append(rows, row)
+	}
+
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+	if len(rows) == 0 {
+		fmt.Println("  " + deps.Theme.Provider.Render("no repositories found"))
+		return nil
+	}
+	table, errList := cli.ResultTable(resultHeaders, rows, deps.Theme)
+	fmt.Println(table)
+	return errList
+}
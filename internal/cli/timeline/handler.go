@@ -0,0 +1,231 @@
+package timeline
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+// Format is the timeline output format.
+type Format string
+
+var (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+)
+
+// authorMe is replaced by each repository user email.
+const authorMe = "me"
+
+// Options are the log command options.
+type Options struct {
+	// Since is a duration (e.g. 12h, 3d, 1w, 2m) or a date (2006-01-02).
+	Since string
+	// Author filters commits by author name or email, "me" matches the
+	// configured user email of each repository.
+	Author string
+	// Branches lists commits of all local branches, instead of HEAD.
+	Branches bool
+	Format   Format
+}
+
+// entry is a commit of a single repository.
+type entry struct {
+	repo   string
+	commit git.Commit
+}
+
+// ExecLog renders a chronological timeline of commits across all project
+// repositories.
+//
+// Args: (optional)
+//   - project name
+func ExecLog(opts Options, args []string, deps types.RuntimeCLI) error {
+	switch opts.Format {
+	case "":
+		opts.Format = FormatText
+	case FormatText, FormatMarkdown:
+	default:
+		return fmt.Errorf("unknown format %q, must be one of: %s, %s", opts.Format, FormatText, FormatMarkdown)
+	}
+	since, err := parseSince(opts.Since, time.Now())
+	if err != nil {
+		return err
+	}
+	project, _, err := cli.ParseArgs(args, true, deps)
+	if err != nil {
+		return err
+	}
+
+	entries, errs := collectCommits(project, opts, since, deps)
+	switch opts.Format {
+	case FormatMarkdown:
+		fmt.Print(renderMarkdown(entries))
+	default:
+		fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+		fmt.Print(renderText(entries, deps))
+	}
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// collectCommits merges commits of all cloned repositories, newest first.
+func collectCommits(
+	project domain.Project,
+	opts Options,
+	since time.Time,
+	deps types.RuntimeCLI,
+) ([]entry, []error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		entries = make([]entry, 0)
+		errList = make([]error, 0)
+	)
+	for idx, name := range project.ListReposWithNamespace() {
+		repo, found := project.GetRepo(name, "")
+		if !found || repo.State != domain.RepoStateOK {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			commits, err := repoCommits(repo, opts, since, deps)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errList = append(errList, cli.RepoError(err, repo))
+				return
+			}
+			for _, commit := range commits {
+				entries = append(entries, entry{repo: name, commit: commit})
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].commit.Date.Equal(entries[j].commit.Date) {
+			return entries[i].repo < entries[j].repo
+		}
+		return entries[i].commit.Date.After(entries[j].commit.Date)
+	})
+	return entries, errList
+}
+
+func repoCommits(
+	repo domain.Repository,
+	opts Options,
+	since time.Time,
+	deps types.RuntimeCLI,
+) ([]git.Commit, error) {
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+
+	author := opts.Author
+	if author == authorMe {
+		email, err := deps.Git.UserEmail(ctx, repo.AbsPath)
+		if err != nil {
+			return nil, err
+		}
+		if email == "" {
+			return nil, fmt.Errorf("no user email configured")
+		}
+		author = regexp.QuoteMeta(email)
+	}
+	return deps.Git.Commits(ctx, repo.AbsPath, git.LogOptions{
+		Since:    since,
+		Author:   author,
+		Branches: opts.Branches,
+	})
+}
+
+var sincePattern = regexp.MustCompile(`^(\d+)([hdwmy])$`)
+
+// parseSince returns the time a duration ago, or a parsed date.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return date, nil
+	}
+	matches := sincePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf(
+			"invalid since %q, use a duration (e.g. 12h, 3d, 1w, 2m, 1y) or a date (e.g. 2006-01-02)",
+			value,
+		)
+	}
+	n, _ := strconv.Atoi(matches[1])
+	switch matches[2] {
+	case "h":
+		return now.Add(-time.Duration(n) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, -n), nil
+	case "w":
+		return now.AddDate(0, 0, -7*n), nil
+	case "m":
+		return now.AddDate(0, -n, 0), nil
+	default:
+		return now.AddDate(-n, 0, 0), nil
+	}
+}
+
+// renderText returns the timeline with aligned repository names.
+func renderText(entries []entry, deps types.RuntimeCLI) string {
+	if len(entries) == 0 {
+		return "  " + deps.Theme.Provider.Render("no commits found") + "\n"
+	}
+	maxLen := 0
+	for _, e := range entries {
+		maxLen = max(maxLen, len(e.repo))
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(
+			&b,
+			"  %s  %s  %s %s %s\n",
+			deps.Theme.Provider.Render(e.commit.Date.Format("2006-01-02 15:04")),
+			deps.Theme.RepoTitle.Width(maxLen).Render(e.repo),
+			deps.Theme.GitOutput.Render(e.commit.ShortHash()),
+			e.commit.Subject,
+			deps.Theme.Provider.Render("("+e.commit.Author+")"),
+		)
+	}
+	return b.String()
+}
+
+// renderMarkdown returns the timeline as a markdown table.
+func renderMarkdown(entries []entry) string {
+	var b strings.Builder
+	b.WriteString("| Date | Repository | Commit | Subject | Author |\n")
+	b.WriteString("| ---- | ---------- | ------ | ------- | ------ |\n")
+	escape := strings.NewReplacer("|", `\|`)
+	for _, e := range entries {
+		fmt.Fprintf(
+			&b,
+			"| %s | %s | `%s` | %s | %s |\n",
+			e.commit.Date.Format("2006-01-02 15:04"),
+			escape.Replace(e.repo),
+			e.commit.ShortHash(),
+			escape.Replace(e.commit.Subject),
+			escape.Replace(e.commit.Author),
+		)
+	}
+	return b.String()
+}
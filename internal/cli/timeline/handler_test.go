@@ -0,0 +1,37 @@
+package timeline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "12h", want: now.Add(-12 * time.Hour)},
+		{value: "3d", want: time.Date(2024, 3, 12, 10, 0, 0, 0, time.Local)},
+		{value: "1w", want: time.Date(2024, 3, 8, 10, 0, 0, 0, time.Local)},
+		{value: "2m", want: time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local)},
+		{value: "1y", want: time.Date(2023, 3, 15, 10, 0, 0, 0, time.Local)},
+		{value: "2024-01-02", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+		{value: "3 days", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "2024-13-01", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
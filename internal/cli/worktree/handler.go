@@ -0,0 +1,366 @@
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rafi/gits/domain"
+	"github.com/rafi/gits/internal/cli"
+	"github.com/rafi/gits/internal/types"
+	"github.com/rafi/gits/pkg/git"
+)
+
+// ExecAdd creates a linked worktree for a branch in project repositories,
+// under a per-branch sibling directory of the project. Missing branches are
+// created from the fetched origin default branch.
+//
+// Args:
+//   - project name
+//   - branch name
+//   - repo names (optional, defaults to all)
+func ExecAdd(args []string, deps types.RuntimeCLI) error {
+	project, err := parseProject(args, deps)
+	if err != nil {
+		return err
+	}
+	branch, repos := args[1], args[2:]
+	deps.Git = deps.Git.Batch()
+	errs := eachRepo(project, project.AbsPath, repos, deps, func(repo domain.Repository) Response {
+		return addRepo(project.AbsPath, repo, branch, deps)
+	})
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// ExecList displays linked worktrees grouped by repository.
+//
+// Args: (optional)
+//   - project name
+//   - branch name
+func ExecList(args []string, deps types.RuntimeCLI) error {
+	project, err := parseProject(args, deps)
+	if err != nil {
+		return err
+	}
+	branch := ""
+	if len(args) > 1 {
+		branch = args[1]
+	}
+	errs := listProject(project, branch, deps)
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+// ExecRemove removes the linked worktrees of a branch in project
+// repositories, refusing worktrees with uncommitted changes.
+//
+// Args:
+//   - project name
+//   - branch name
+//   - repo names (optional, defaults to all)
+func ExecRemove(args []string, deps types.RuntimeCLI) error {
+	project, err := parseProject(args, deps)
+	if err != nil {
+		return err
+	}
+	branch, repos := args[1], args[2:]
+	errs := eachRepo(project, project.AbsPath, repos, deps, func(repo domain.Repository) Response {
+		return removeRepo(project.AbsPath, repo, branch, deps)
+	})
+	if project.AbsPath != "" {
+		// Remove the per-branch directory, only if nothing is left in it.
+		_ = removeEmptyDirs(branchDir(project.AbsPath, branch))
+	}
+	if len(errs) > 0 {
+		return cli.RenderErrors(errs, true)
+	}
+	return nil
+}
+
+type Response struct {
+	output     string
+	title      lipgloss.Style
+	error      error
+	errorStyle lipgloss.Style
+}
+
+func (r Response) String() string {
+	if r.error != nil {
+		return fmt.Sprintf("%s %s", r.title, r.errorStyle.Render(r.error.Error()))
+	}
+
+	return fmt.Sprintf(
+		"%s %s",
+		r.title.Render(),
+		r.output,
+	)
+}
+
+// parseProject loads a project from the first argument, or interactively.
+func parseProject(args []string, deps types.RuntimeCLI) (domain.Project, error) {
+	if len(args) > 1 {
+		args = args[:1]
+	}
+	project, _, err := cli.ParseArgs(args, true, deps)
+	return project, err
+}
+
+// eachRepo runs fn concurrently on project repositories, optionally
+// filtered by names, and renders its responses.
+func eachRepo(
+	project domain.Project,
+	rootPath string,
+	names []string,
+	deps types.RuntimeCLI,
+	fn func(domain.Repository) Response,
+) []error {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errList = make([]error, 0)
+		maxLen  = cli.GetMaxLen(project)
+		idx     = 0
+	)
+	for _, repo := range project.Repos {
+		if len(names) > 0 && !repo.ContainedIn(names) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := fn(repo)
+			resp.title = resp.title.Width(maxLen)
+			fmt.Println(resp)
+			if resp.error != nil {
+				mu.Lock()
+				errList = append(errList, resp.error)
+				mu.Unlock()
+			}
+		}()
+		if idx > 0 && idx%deps.Settings.WorkerCount == 0 {
+			wg.Wait()
+		}
+		idx++
+	}
+	wg.Wait()
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		errs := eachRepo(subProject, rootPath, names, deps, fn)
+		errList = append(errList, errs...)
+	}
+	return errList
+}
+
+func addRepo(rootPath string, repo domain.Repository, branch string, deps types.RuntimeCLI) Response {
+	resp := Response{
+		title:      cli.RepoTitle(repo, rootPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+
+	dest := worktreePath(rootPath, repo, branch)
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		resp.error = types.NewWarning("already exists at %s", cli.Path(dest, deps.HomeDir))
+		return resp
+	}
+
+	// Adding a worktree checks out a full working copy, like a clone.
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Clone)
+	defer cancel()
+
+	gitRepo, err := deps.Git.Open(repo.AbsPath)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	base := ""
+	if !gitRepo.IsLocalBranch(ctx, branch) {
+		// Fetch first, so new branches start from up-to-date code.
+		fetchCtx, cancelFetch := cli.WithTimeout(ctx, deps.Settings.Timeouts.Fetch)
+		_, err := deps.Git.Fetch(fetchCtx, repo.AbsPath)
+		cancelFetch()
+		if err != nil {
+			resp.error = cli.RepoError(err, repo)
+			return resp
+		}
+		if !gitRepo.IsRemoteBranch(ctx, domain.RemoteOrigin, branch) {
+			defaultBranch, err := deps.Git.DefaultBranch(ctx, repo.AbsPath, domain.RemoteOrigin)
+			if err != nil {
+				resp.error = cli.RepoError(err, repo)
+				return resp
+			}
+			base = domain.RemoteOrigin + "/" + defaultBranch
+		}
+	}
+
+	resp.output, err = deps.Git.AddWorktree(ctx, repo.AbsPath, dest, branch, base)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.output = deps.Theme.GitOutput.Render(cli.Path(dest, deps.HomeDir))
+	return resp
+}
+
+func removeRepo(rootPath string, repo domain.Repository, branch string, deps types.RuntimeCLI) Response {
+	resp := Response{
+		title:      cli.RepoTitle(repo, rootPath, deps.HomeDir, deps.Theme),
+		errorStyle: deps.Theme.Error,
+	}
+
+	// Abort if repository is not cloned or has errors.
+	if repo.State != domain.RepoStateOK {
+		resp.error = cli.AbortOnRepoState(repo, deps.Theme.Error)
+		return resp
+	}
+
+	dest := worktreePath(rootPath, repo, branch)
+	destPath := cli.Path(dest, deps.HomeDir)
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		resp.error = types.NewWarning("no worktree at %s", destPath)
+		return resp
+	}
+
+	ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+	defer cancel()
+	status, err := deps.Git.Status(ctx, dest)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	if status.Staged+status.Unstaged+status.Untracked+status.Conflicted > 0 {
+		err := fmt.Errorf("uncommitted changes in %s", destPath)
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+
+	_, err = deps.Git.RemoveWorktree(ctx, repo.AbsPath, dest)
+	if err != nil {
+		resp.error = cli.RepoError(err, repo)
+		return resp
+	}
+	resp.output = deps.Theme.GitOutput.Render("removed " + destPath)
+	return resp
+}
+
+func listProject(project domain.Project, branch string, deps types.RuntimeCLI) []error {
+	fmt.Println(cli.ProjectTitleWithBullet(project, deps.Theme))
+
+	errList := make([]error, 0)
+	maxLen := cli.GetMaxLen(project)
+	for _, repo := range project.Repos {
+		if repo.State != domain.RepoStateOK {
+			continue
+		}
+		ctx, cancel := cli.WithTimeout(deps.Context, deps.Settings.Timeouts.Status)
+		worktrees, err := deps.Git.Worktrees(ctx, repo.AbsPath)
+		cancel()
+		if err != nil {
+			errList = append(errList, cli.RepoError(err, repo))
+			continue
+		}
+
+		linked := make([]git.Worktree, 0, len(worktrees))
+		for _, worktree := range worktrees {
+			if !worktree.Main && (branch == "" || worktree.Branch == branch) {
+				linked = append(linked, worktree)
+			}
+		}
+		if len(linked) == 0 {
+			continue
+		}
+
+		// Display repository title once, aligned with its worktrees.
+		title := cli.RepoTitle(repo, project.AbsPath, deps.HomeDir, deps.Theme).
+			Width(maxLen).
+			Align(lipgloss.Right)
+		for idx, worktree := range linked {
+			if idx > 0 {
+				title = title.SetString("")
+			}
+			fmt.Printf("%s %s %s\n",
+				title.Render(),
+				deps.Theme.GitOutput.Render(cli.Path(worktree.Path, deps.HomeDir)),
+				renderRef(worktree, deps),
+			)
+		}
+	}
+
+	for _, subProject := range project.SubProjects {
+		fmt.Println()
+		errs := listProject(subProject, branch, deps)
+		errList = append(errList, errs...)
+	}
+	return errList
+}
+
+// renderRef returns the checked out branch or commit of a worktree.
+func renderRef(worktree git.Worktree, deps types.RuntimeCLI) string {
+	ref := worktree.Branch
+	if worktree.Detached || ref == "" {
+		ref = deps.Settings.Icons.Detached + worktree.Commit[:min(7, len(worktree.Commit))]
+	}
+	flags := []string{}
+	if worktree.Locked {
+		flags = append(flags, "locked")
+	}
+	if worktree.Prunable {
+		flags = append(flags, "prunable")
+	}
+	if len(flags) > 0 {
+		ref += " " + deps.Theme.Error.Render(strings.Join(flags, ","))
+	}
+	return "[" + ref + "]"
+}
+
+// branchDir returns the per-branch sibling directory of a path.
+func branchDir(path, branch string) string {
+	return filepath.Clean(path) + "@" + strings.ReplaceAll(branch, "/", "-")
+}
+
+// worktreePath returns the linked worktree path of a repository branch, e.g.
+// ~/code/acme/api and branch feat/x will use ~/code/acme@feat-x/api
+func worktreePath(rootPath string, repo domain.Repository, branch string) string {
+	if rootPath != "" {
+		rel, err := filepath.Rel(rootPath, repo.AbsPath)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join(branchDir(rootPath, branch), rel)
+		}
+	}
+	return branchDir(repo.AbsPath, branch)
+}
+
+// removeEmptyDirs removes a directory tree, only if it contains no files.
+func removeEmptyDirs(path string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			return errors.New("directory not empty")
+		}
+		if err := removeEmptyDirs(filepath.Join(path, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Remove(path)
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/types"
-	"github.com/rafi/gits/pkg/git"
 	"github.com/rafi/gits/pkg/providers"
 )
 
@@ -85,9 +84,9 @@ func populateProject(project *domain.Project, deps types.Runtime) error {
 	switch {
 	case emptySource && len(project.Repos) > 0 && project.Path == "":
 		// Process repos individually if project doesn't have a path.
-		var err error
 		for repoIdx, repo := range project.Repos {
-			project.Repos[repoIdx], err = providers.NewFilesystemRepo(
+			discovered, err := providers.NewFilesystemRepo(
+				deps.Context,
 				repo.Dir,
 				repo.Src,
 				deps.Git,
@@ -95,6 +94,7 @@ func populateProject(project *domain.Project, deps types.Runtime) error {
 			if err != nil {
 				return err
 			}
+			project.Repos[repoIdx] = mergeFilesystemRepo(repo, discovered)
 		}
 
 	case emptySource && len(project.Repos) == 0:
@@ -120,13 +120,25 @@ func populateProject(project *domain.Project, deps types.Runtime) error {
 	}
 
 	// Load any remote sources, and check repositories state.
-	computeState(project, deps.Git)
+	computeState(project, deps)
 
 	// Filter by user include/exclude config values.
 	project.Filter()
 	return nil
 }
 
+// mergeFilesystemRepo returns the configured repository, with the fields
+// discovered from its filesystem clone. Other configured fields are kept.
+func mergeFilesystemRepo(configured, discovered domain.Repository) domain.Repository {
+	merged := configured
+	merged.Name = discovered.Name
+	merged.Dir = discovered.Dir
+	merged.Src = discovered.Src
+	merged.State = discovered.State
+	merged.Reason = discovered.Reason
+	return merged
+}
+
 // getSource populates project repos from a provider source.
 func getSource(project *domain.Project, deps types.Runtime) error {
 	var (
@@ -165,7 +177,7 @@ func getSource(project *domain.Project, deps types.Runtime) error {
 		} else {
 			log.Debugf("Fetching %s repos from %s…", source.Type, source.Search)
 		}
-		if err := c.LoadRepos(source.Search, deps.Git, project); err != nil {
+		if err := c.LoadRepos(deps.Context, source.Search, deps.Git, project); err != nil {
 			return fmt.Errorf(
 				"Failed to load repos for %q project (%s): %w"+
 					project.Name,
@@ -188,7 +200,7 @@ func getSource(project *domain.Project, deps types.Runtime) error {
 }
 
 // computeState evaluates project's repos state.
-func computeState(project *domain.Project, git git.Git) {
+func computeState(project *domain.Project, deps types.Runtime) {
 	var err error
 	if project.Path != "" {
 		project.AbsPath, err = homedir.Expand(project.Path)
@@ -204,12 +216,22 @@ func computeState(project *domain.Project, git git.Git) {
 		if sub.Source == nil {
 			sub.Source = project.Source
 		}
-		computeState(sub, git)
+		if sub.Submodules == domain.SubmodulesNone {
+			sub.Submodules = project.Submodules
+		}
+		sub.CloneOptions = project.CloneOptions.Merge(sub.CloneOptions)
+		sub.PullOptions = project.PullOptions.Merge(sub.PullOptions)
+		computeState(sub, deps)
 	}
 
 	for repoIdx := range project.Repos {
 		r := &project.Repos[repoIdx]
 		r.State = domain.RepoStateUnknown
+		if r.Submodules == domain.SubmodulesNone {
+			r.Submodules = project.Submodules
+		}
+		r.CloneOptions = project.CloneOptions.Merge(r.CloneOptions)
+		r.PullOptions = project.PullOptions.Merge(r.PullOptions)
 
 		if project.Source != nil {
 			r.Type = project.Source.Type
@@ -237,7 +259,7 @@ func computeState(project *domain.Project, git git.Git) {
 		}
 
 		if r.Src == "" {
-			r.Src, err = git.Remote(r.AbsPath)
+			r.Src, err = deps.Git.Remote(deps.Context, r.AbsPath)
 			if err != nil {
 				r.State = domain.RepoStateError
 				r.Reason = err.Error()
@@ -246,7 +268,7 @@ func computeState(project *domain.Project, git git.Git) {
 
 		if _, err := os.Stat(r.AbsPath); os.IsNotExist(err) {
 			r.State = domain.RepoStateNoLocal
-		} else if !git.IsRepo(r.AbsPath) {
+		} else if !deps.Git.IsRepo(r.AbsPath) {
 			r.State = domain.RepoStateError
 			r.Reason = "Unable to load repo"
 			continue
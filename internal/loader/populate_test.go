@@ -0,0 +1,35 @@
+package loader
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafi/gits/domain"
+)
+
+func TestMergeFilesystemRepo(t *testing.T) {
+	configured := domain.Repository{
+		Dir:          "~/code/api",
+		Remotes:      map[string]string{domain.RemoteUpstream: "git@example.com:up/api.git"},
+		Submodules:   domain.SubmodulesRecursive,
+		CloneOptions: &domain.CloneOptions{Depth: 1},
+		PullOptions:  &domain.PullOptions{Strategy: domain.PullRebase},
+	}
+	discovered := domain.Repository{
+		Name:   "api",
+		Dir:    "~/code/api",
+		Src:    "git@example.com:acme/api.git",
+		State:  domain.RepoStateError,
+		Reason: "unable to get remote URL",
+	}
+
+	want := configured
+	want.Name = "api"
+	want.Src = "git@example.com:acme/api.git"
+	want.State = domain.RepoStateError
+	want.Reason = "unable to get remote URL"
+
+	if got := mergeFilesystemRepo(configured, discovered); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeFilesystemRepo() = %+v, want %+v", got, want)
+	}
+}
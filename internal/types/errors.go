@@ -10,6 +10,7 @@ type Type int
 const (
 	ErrorType Type = iota
 	WarningType
+	CancelledType
 )
 
 type Warning struct {
@@ -17,6 +18,7 @@ type Warning struct {
 	Title  string
 	Reason string
 	Dir    string
+	Err    error
 }
 
 func NewWarning(reason string, args ...interface{}) error {
@@ -33,6 +35,10 @@ func NewError(reason string, args ...interface{}) error {
 	}
 }
 
+func (e Warning) Unwrap() error {
+	return e.Err
+}
+
 func (e Warning) Error() string {
 	msg := ""
 	if e.Title != "" {
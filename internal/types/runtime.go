@@ -1,6 +1,8 @@
 package types
 
 import (
+	"context"
+
 	"github.com/rafi/gits/domain"
 	"github.com/rafi/gits/internal/cache"
 	"github.com/rafi/gits/internal/cli/config"
@@ -9,6 +11,8 @@ import (
 
 // Runtime is the runtime dependencies for the application.
 type Runtime struct {
+	// Context is cancelled on interrupt, and bounds all git executions.
+	Context    context.Context
 	Projects   domain.ProjectListKeyed
 	Cache      cache.Cacher
 	ConfigPath string
@@ -13,7 +13,8 @@ import (
 const fzfBin = "fzf"
 
 type FZF struct {
-	Args []string
+	Args  []string
+	multi bool
 }
 
 var (
@@ -55,6 +56,11 @@ func (f *FZF) WithPrompt(label string) {
 	f.Args = append(f.Args, "--prompt", label)
 }
 
+// WithMulti allows selecting multiple lines, one per line in the result.
+func (f *FZF) WithMulti() {
+	f.multi = true
+}
+
 // Run executes fzf with given args and stdin.
 func (f *FZF) Run(stdin bytes.Buffer) (string, error) {
 	_, err := exec.LookPath(fzfBin)
@@ -64,6 +70,9 @@ func (f *FZF) Run(stdin bytes.Buffer) (string, error) {
 
 	// Default options
 	args := append(f.Args, defaultOpts...)
+	if f.multi {
+		args = append(args, "--multi", "--bind=ctrl-a:select-all")
+	}
 	if os.Getenv("FZF_DEFAULT_OPTS") == "" {
 		args = append(args, defaultLayoutOpts...)
 	}
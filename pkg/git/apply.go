@@ -0,0 +1,17 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// ApplyPatch applies a patch to the working tree and index, falling back to
+// a 3-way merge which leaves conflicts to resolve.
+func (g *Shell) ApplyPatch(ctx context.Context, path, patch string) (string, error) {
+	args := []string{"apply", "--3way", patch}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to apply patch: %w", err)
+	}
+	return cleanOutput(output), nil
+}
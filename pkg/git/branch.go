@@ -0,0 +1,157 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LocalBranch is a local branch, and its relation to upstream and target.
+type LocalBranch struct {
+	Name     string
+	Upstream string
+	// Gone is true if the upstream is set, but no longer exists.
+	Gone bool
+	// Merged is true if the branch is fully merged into target.
+	Merged bool
+	// Empty is true if the branch has no commits of its own, its tip is on
+	// the first-parent history of target, e.g. a branch just created from it.
+	Empty bool
+}
+
+// UnpushedBranch is a local branch with commits not found on any remote.
+type UnpushedBranch struct {
+	Name     string
+	Upstream string
+	Commits  int
+	// Detached is a detached HEAD, its commits are on no remote or branch.
+	Detached bool
+}
+
+// branchFormat separates branch fields with the ASCII unit separator.
+const branchFormat = "--format=%(refname:short)%1f%(upstream:short)%1f%(upstream:track)%1f%(objectname)"
+
+// LocalBranches returns local branches, and whether they are merged into
+// target, have no commits of their own, or their upstream is gone.
+func (g *Shell) LocalBranches(ctx context.Context, path, target string) ([]LocalBranch, error) {
+	args := []string{"for-each-ref", branchFormat, "refs/heads"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	args = []string{"for-each-ref", "--format=%(refname:short)", "--merged=" + target, "refs/heads"}
+	merged, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list merged branches: %w", err)
+	}
+	mergedNames := strings.Split(cleanOutput(merged), "\n")
+	args = []string{"rev-list", "--first-parent", target}
+	mainline, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list target history: %w", err)
+	}
+	mainlineHashes := map[string]bool{}
+	for _, hash := range strings.Split(cleanOutput(mainline), "\n") {
+		mainlineHashes[hash] = true
+	}
+
+	branches := []LocalBranch{}
+	for _, line := range strings.Split(cleanOutput(output), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 || fields[0] == "" {
+			continue
+		}
+		branches = append(branches, LocalBranch{
+			Name:     fields[0],
+			Upstream: fields[1],
+			Gone:     fields[2] == "[gone]",
+			Merged:   slices.Contains(mergedNames, fields[0]),
+			Empty:    mainlineHashes[fields[3]],
+		})
+	}
+	return branches, nil
+}
+
+// CreateBranch creates a branch from base, without checking it out.
+func (g *Shell) CreateBranch(ctx context.Context, path, name, base string) (string, error) {
+	args := []string{"branch", "--no-track", name, base}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to create branch: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// DeleteBranch deletes a branch, refusing branches not merged into their
+// upstream or HEAD, unless forced.
+func (g *Shell) DeleteBranch(ctx context.Context, path, name string, force bool) (string, error) {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	args := []string{"branch", flag, name}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to delete branch: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// RenameBranch renames a branch, refusing to overwrite an existing one.
+func (g *Shell) RenameBranch(ctx context.Context, path, name, newName string) (string, error) {
+	args := []string{"branch", "-m", name, newName}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to rename branch: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// UnpushedBranches returns local branches with commits not found on any
+// remote, and the number of those commits. A detached HEAD is included if it
+// has commits not found on any remote or branch.
+func (g *Shell) UnpushedBranches(ctx context.Context, path string) ([]UnpushedBranch, error) {
+	args := []string{"for-each-ref", "--format=%(refname:short)%1f%(upstream:short)", "refs/heads"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	branches := []UnpushedBranch{}
+	for _, line := range strings.Split(cleanOutput(output), "\n") {
+		name, upstream, found := strings.Cut(line, "\x1f")
+		if !found || name == "" {
+			continue
+		}
+		args := []string{"rev-list", "--count", "refs/heads/" + name, "--not", "--remotes"}
+		output, err := g.Exec(ctx, path, args)
+		if err != nil {
+			return nil, fmt.Errorf("unable to count unpushed commits: %w", err)
+		}
+		count, err := strconv.Atoi(cleanOutput(output))
+		if err != nil {
+			return nil, fmt.Errorf("unable to count unpushed commits: %w", err)
+		}
+		if count > 0 {
+			branches = append(branches, UnpushedBranch{Name: name, Upstream: upstream, Commits: count})
+		}
+	}
+
+	// Commits on a detached HEAD are only referenced by the reflog.
+	if _, err := g.Exec(ctx, path, []string{"symbolic-ref", "--quiet", "HEAD"}); err == nil {
+		return branches, nil
+	}
+	args = []string{"rev-list", "--count", "HEAD", "--not", "--remotes", "--branches"}
+	if output, err = g.Exec(ctx, path, args); err != nil {
+		return nil, fmt.Errorf("unable to count detached commits: %w", err)
+	}
+	count, err := strconv.Atoi(cleanOutput(output))
+	if err != nil {
+		return nil, fmt.Errorf("unable to count detached commits: %w", err)
+	}
+	if count > 0 {
+		branches = append(branches, UnpushedBranch{Name: "HEAD", Commits: count, Detached: true})
+	}
+	return branches, nil
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// Bundle creates a bundle file of refs, or of all refs if none provided.
+func (g *Shell) Bundle(ctx context.Context, path, file string, refs []string) (string, error) {
+	args := []string{"bundle", "create", file}
+	if len(refs) == 0 {
+		args = append(args, "--all")
+	} else {
+		args = append(args, refs...)
+	}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to create bundle: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// SetRemoteURL changes the URL of a remote.
+func (g *Shell) SetRemoteURL(ctx context.Context, path, name, url string) error {
+	args := []string{"remote", "set-url", name, url}
+	if _, err := g.Exec(ctx, path, args); err != nil {
+		return fmt.Errorf("unable to set remote url: %w", err)
+	}
+	return nil
+}
@@ -1,73 +1,93 @@
 package git
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/go-git/go-git/v6"
 	log "github.com/sirupsen/logrus"
 )
 
-type Git struct {
-	bin string
+// Shell is a git client that runs the git executable.
+type Shell struct {
+	bin   string
+	batch bool
 }
 
-// NewGit returns a new Git client.
-func NewGit() (g Git, err error) {
-	// Find executable path.
-	g.bin, err = exec.LookPath("git")
+// NewShell returns a git client that runs the git executable.
+func NewShell() (*Shell, error) {
+	bin, err := exec.LookPath("git")
 	if err != nil {
-		log.Warnf("unable to find git executable: %s", err)
+		return nil, fmt.Errorf("unable to find git executable: %w", err)
 	}
-	return g, nil
+	return &Shell{bin: bin}, nil
 }
 
 // Clone clones repository to filesystem.
-func (g *Git) Clone(remote string, path string) (string, error) {
+func (g *Shell) Clone(ctx context.Context, remote string, path string, opts CloneOptions) (string, error) {
+	if err := prepareClonePath(path); err != nil {
+		return "", err
+	}
+
+	args := append([]string{"clone"}, opts.args()...)
+	args = append(args, remote, path)
+	output, err := g.Exec(ctx, filepath.Dir(path), args)
+	if err != nil {
+		return "", fmt.Errorf("unable to clone: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// prepareClonePath ensures a clone path doesn't exist, and its parent does.
+func prepareClonePath(path string) error {
 	if _, err := os.Stat(path); !os.IsNotExist(err) {
-		return "", fmt.Errorf("directory already exists")
+		return fmt.Errorf("directory already exists")
 	}
 
 	basePath := filepath.Dir(path)
 	if _, err := os.Stat(basePath); os.IsNotExist(err) {
 		if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
-			return "", err
+			return err
 		}
 		log.Debugf("Created directory %s", basePath)
 	}
+	return nil
+}
 
-	args := []string{"clone", remote, path}
-	output, err := g.Exec(basePath, args)
-	if err != nil {
-		return "", fmt.Errorf("unable to clone: %s %w", output, err)
-	}
-	return cleanOutput(output), nil
+// Batch returns a copy of the client that disables git terminal prompts.
+func (g *Shell) Batch() Git {
+	batch := *g
+	batch.batch = true
+	return &batch
 }
 
 // Open returns a git repository client.
-func (g *Git) Open(path string) (repo Repository, err error) {
-	repo.client, err = git.PlainOpen(path)
-	if err != nil {
-		return repo, fmt.Errorf("failed to open git repository: %w", err)
+func (g *Shell) Open(path string) (Repository, error) {
+	if !g.IsRepo(path) {
+		return nil, fmt.Errorf("failed to open git repository: %s", path)
 	}
-	return repo, nil
+	return &shellRepository{shell: g, path: path}, nil
 }
 
 // IsRepo checks if the directory is a git repository.
-func (g *Git) IsRepo(path string) bool {
+func (g *Shell) IsRepo(path string) bool {
 	_, err := git.PlainOpen(path)
 	return err == nil
 }
 
 // Remote expand the URL of the current remote, taking into account any
 // "url.<base>.insteadOf" config setting.
-func (g *Git) Remote(path string) (string, error) {
+func (g *Shell) Remote(ctx context.Context, path string) (string, error) {
 	args := []string{"ls-remote", "--get-url"}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("unable to get remote URL: %w", err)
 	}
@@ -75,26 +95,16 @@ func (g *Git) Remote(path string) (string, error) {
 }
 
 // Fetch fetches all remotes, tags and prunes deleted branches.
-func (g *Git) Fetch(path string) (string, error) {
+func (g *Shell) Fetch(ctx context.Context, path string) (string, error) {
 	args := []string{"fetch", "--all", "--tags", "--prune", "--force"}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("error during fetch: %w", err)
 	}
 	return cleanOutput(output), nil
 }
 
-// Pull fetches from remote and merges the current branch.
-func (g *Git) Pull(path string) (string, error) {
-	args := []string{"pull", "--ff-only", "--stat", "--no-verbose"}
-	output, err := g.Exec(path, args)
-	if err != nil {
-		return "", fmt.Errorf("error during pull: %w", err)
-	}
-	return cleanOutput(output), nil
-}
-
-func (g *Git) Log(path, ref string) (string, error) {
+func (g *Shell) Log(ctx context.Context, path, ref string) (string, error) {
 	args := []string{
 		"log",
 		"-15",
@@ -106,14 +116,14 @@ func (g *Git) Log(path, ref string) (string, error) {
 	if len(ref) > 0 {
 		args = append(args, ref)
 	}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("error during log: %w", err)
 	}
 	return cleanOutput(output), nil
 }
 
-func (g *Git) CommitDates(path, branch string, days int) ([]string, error) {
+func (g *Shell) CommitDates(ctx context.Context, path, branch string, days int) ([]string, error) {
 	args := []string{
 		"log",
 		"--format=format:%ad",
@@ -121,14 +131,14 @@ func (g *Git) CommitDates(path, branch string, days int) ([]string, error) {
 		fmt.Sprintf("--since=%d days ago", days),
 		branch,
 	}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return nil, fmt.Errorf("error during commit dates: %w", err)
 	}
 	return strings.Split(cleanOutput(output), "\n"), nil
 }
 
-func (g *Git) Refs(path string) ([]string, error) {
+func (g *Shell) Refs(ctx context.Context, path string) ([]string, error) {
 	args := []string{
 		"for-each-ref",
 		"--format=%(refname)",
@@ -136,26 +146,56 @@ func (g *Git) Refs(path string) ([]string, error) {
 		"refs/tags",
 		"--sort=-committerdate",
 	}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return nil, fmt.Errorf("error during commit dates: %w", err)
 	}
 	return strings.Split(cleanOutput(output), "\n"), nil
 }
 
-// Exec executes git command-line with provided arguments.
-func (g *Git) Exec(path string, args []string) ([]byte, error) {
-	var (
-		cmdOut []byte
-		err    error
-	)
+// Exec executes git command-line with provided arguments, and returns the
+// combined output. Failures are returned as a classified *Error. When the
+// context is cancelled or its deadline exceeded, the git process and its
+// children are killed and the context error is returned.
+func (g *Shell) Exec(ctx context.Context, path string, args []string) ([]byte, error) {
+	var cmdOut, cmdErr bytes.Buffer
 	args = append([]string{"-C", path}, args...)
 
-	cmd := exec.CommandContext(context.TODO(), g.bin, args...)
-	if cmdOut, err = cmd.CombinedOutput(); err != nil {
-		return cmdOut, err
+	// Output streams are copied concurrently, serialize writes to the
+	// combined output.
+	out := &lockedWriter{w: &cmdOut}
+	cmd := exec.CommandContext(ctx, g.bin, args...)
+	cmd.Stdout = out
+	cmd.Stderr = io.MultiWriter(out, &cmdErr)
+	// Errors and some outputs are parsed, force untranslated git messages.
+	cmd.Env = append(os.Environ(), "LC_ALL=C", "LANGUAGE=")
+	if g.batch {
+		cmd.Env = append(cmd.Env, "GIT_TERMINAL_PROMPT=0")
+	}
+	setProcessGroup(cmd)
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return cmdOut.Bytes(), ctxErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return cmdOut.Bytes(), newError(cmdErr.String(), err)
+		}
+		return cmdOut.Bytes(), err
 	}
-	return cmdOut, nil
+	return cmdOut.Bytes(), nil
+}
+
+// lockedWriter is a writer safe for concurrent use.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
 }
 
 func cleanOutput(output []byte) string {
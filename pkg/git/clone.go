@@ -0,0 +1,38 @@
+package git
+
+import "strconv"
+
+// CloneOptions limit the history, objects or branches fetched by a clone.
+type CloneOptions struct {
+	// Depth truncates history to the specified number of commits.
+	Depth int
+	// Filter is a partial clone object filter, e.g. blob:none.
+	Filter string
+	// SingleBranch clones only the history of one branch.
+	SingleBranch bool
+	// Branch is checked out instead of the remote HEAD.
+	Branch string
+	// Reference is a local repository to borrow objects from, if it exists.
+	Reference string
+}
+
+// args returns the 'git clone' arguments of the options.
+func (o CloneOptions) args() []string {
+	var args []string
+	if o.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(o.Depth))
+	}
+	if o.Filter != "" {
+		args = append(args, "--filter="+o.Filter)
+	}
+	if o.SingleBranch {
+		args = append(args, "--single-branch")
+	}
+	if o.Branch != "" {
+		args = append(args, "--branch", o.Branch)
+	}
+	if o.Reference != "" {
+		args = append(args, "--reference-if-able", o.Reference)
+	}
+	return args
+}
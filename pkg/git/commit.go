@@ -0,0 +1,97 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FileStat is the change summary of a single file, relative to HEAD.
+type FileStat struct {
+	Path    string
+	Added   int
+	Deleted int
+	// Binary files have no line counts.
+	Binary bool
+	// Untracked files have no line counts.
+	Untracked bool
+}
+
+// ChangedFiles returns staged, unstaged and untracked files, relative to HEAD.
+func (g *Shell) ChangedFiles(ctx context.Context, path string) ([]FileStat, error) {
+	base, err := g.diffBase(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"diff", base, "--numstat", "--no-renames", "-z"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get diff: %w", err)
+	}
+	files := parseNumstat(output)
+
+	args = []string{"ls-files", "--others", "--exclude-standard", "-z"}
+	output, err = g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list untracked files: %w", err)
+	}
+	for _, name := range bytes.Split(output, []byte{0}) {
+		if len(name) > 0 {
+			files = append(files, FileStat{Path: string(name), Untracked: true})
+		}
+	}
+	return files, nil
+}
+
+// diffBase returns HEAD, or the empty tree if there are no commits yet.
+func (g *Shell) diffBase(ctx context.Context, path string) (string, error) {
+	args := []string{"rev-parse", "--verify", "--quiet", "HEAD"}
+	if _, err := g.Exec(ctx, path, args); err == nil {
+		return "HEAD", nil
+	}
+	// Hash an empty input, in the repository object format.
+	args = []string{"hash-object", "-t", "tree", "--stdin"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to get empty tree: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// parseNumstat parses NUL-terminated numstat output, without renames.
+func parseNumstat(output []byte) []FileStat {
+	files := []FileStat{}
+	for _, line := range bytes.Split(output, []byte{0}) {
+		fields := strings.SplitN(string(line), "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		file := FileStat{Path: fields[2]}
+		if fields[0] == "-" {
+			file.Binary = true
+		} else {
+			file.Added, _ = strconv.Atoi(fields[0])
+			file.Deleted, _ = strconv.Atoi(fields[1])
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// CommitAll stages all changes, including untracked files, and commits them.
+func (g *Shell) CommitAll(ctx context.Context, path, message string) (string, error) {
+	if _, err := g.Exec(ctx, path, []string{"add", "--all"}); err != nil {
+		return "", fmt.Errorf("unable to stage changes: %w", err)
+	}
+	args := []string{"commit", "--quiet", "--message", message}
+	if _, err := g.Exec(ctx, path, args); err != nil {
+		return "", fmt.Errorf("unable to commit: %w", err)
+	}
+	output, err := g.Exec(ctx, path, []string{"rev-parse", "HEAD"})
+	if err != nil {
+		return "", fmt.Errorf("unable to find commit: %w", err)
+	}
+	return cleanOutput(output), nil
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNumstat(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []FileStat
+	}{
+		{
+			name:   "empty",
+			output: "",
+			want:   []FileStat{},
+		},
+		{
+			name:   "text and binary files",
+			output: "3\t1\tmain.go\x00-\t-\tlogo.png\x000\t12\tdir/with\ttab.go\x00",
+			want: []FileStat{
+				{Path: "main.go", Added: 3, Deleted: 1},
+				{Path: "logo.png", Binary: true},
+				{Path: "dir/with\ttab.go", Deleted: 12},
+			},
+		},
+		{
+			name:   "skips malformed entries",
+			output: "3\tmain.go\x00",
+			want:   []FileStat{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseNumstat([]byte(tt.output))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNumstat() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
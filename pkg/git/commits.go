@@ -0,0 +1,96 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogOptions filter the commits listed by Commits.
+type LogOptions struct {
+	// Since excludes commits authored before, zero lists all.
+	Since time.Time
+	// Author is an extended regular expression matched against the commit
+	// author name and email.
+	Author string
+	// Branches lists commits of all local branches, instead of HEAD.
+	Branches bool
+}
+
+// Commit is a single commit summary.
+type Commit struct {
+	Hash    string
+	Author  string
+	Email   string
+	Date    time.Time
+	Subject string
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c Commit) ShortHash() string {
+	return c.Hash[:min(7, len(c.Hash))]
+}
+
+// commitFormat separates commit fields with the ASCII unit separator.
+const commitFormat = "--format=%H%x1f%an%x1f%ae%x1f%at%x1f%s"
+
+// Commits returns commits of HEAD, or of all local branches, newest first.
+func (g *Shell) Commits(ctx context.Context, path string, opts LogOptions) ([]Commit, error) {
+	args := []string{"log", "--no-color", commitFormat}
+	if !opts.Since.IsZero() {
+		args = append(args, "--since="+opts.Since.Format(time.RFC3339))
+	}
+	if opts.Author != "" {
+		args = append(args, "--extended-regexp", "--author="+opts.Author)
+	}
+	if opts.Branches {
+		args = append(args, "--branches")
+	}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("error during log: %w", err)
+	}
+	return parseCommits(cleanOutput(output))
+}
+
+// parseCommits parses log output of commitFormat.
+func parseCommits(output string) ([]Commit, error) {
+	commits := []Commit{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x1f", 5)
+		if len(fields) < 5 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return commits, fmt.Errorf("unable to parse commit date: %w", err)
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Date:    time.Unix(timestamp, 0),
+			Subject: fields[4],
+		})
+	}
+	return commits, nil
+}
+
+// UserEmail returns the configured user email of a repository.
+func (g *Shell) UserEmail(ctx context.Context, path string) (string, error) {
+	args := []string{"config", "user.email"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		// Exit status 1 means the email is not configured.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to get user email: %w", err)
+	}
+	return cleanOutput(output), nil
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCommits(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    []Commit
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			output: "",
+			want:   []Commit{},
+		},
+		{
+			name: "commits",
+			output: "aaaa\x1fJane Doe\x1fjane@example.com\x1f1700000000\x1fAdd feature\n" +
+				"bbbb\x1fJohn\x1fjohn+git@example.com\x1f1600000000\x1fFix: a\x1fb",
+			want: []Commit{
+				{
+					Hash:    "aaaa",
+					Author:  "Jane Doe",
+					Email:   "jane@example.com",
+					Date:    time.Unix(1700000000, 0),
+					Subject: "Add feature",
+				},
+				{
+					Hash:    "bbbb",
+					Author:  "John",
+					Email:   "john+git@example.com",
+					Date:    time.Unix(1600000000, 0),
+					Subject: "Fix: a\x1fb",
+				},
+			},
+		},
+		{
+			name:   "skips short lines",
+			output: "aaaa\x1fJane\n",
+			want:   []Commit{},
+		},
+		{
+			name:    "malformed date",
+			output:  "aaaa\x1fJane\x1fjane@example.com\x1fyesterday\x1fAdd feature",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCommits(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCommits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCommits() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitShortHash(t *testing.T) {
+	tests := []struct {
+		hash string
+		want string
+	}{
+		{hash: "5b58545a1f2e3d4c", want: "5b58545"},
+		{hash: "5b58", want: "5b58"},
+		{hash: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := (Commit{Hash: tt.hash}).ShortHash(); got != tt.want {
+			t.Errorf("ShortHash(%q) = %q, want %q", tt.hash, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// Known causes of git failures, use errors.Is to test an error against them.
+var (
+	ErrAuthFailed      = errors.New("authentication failed")
+	ErrHostUnreachable = errors.New("host unreachable")
+	ErrRepoNotFound    = errors.New("repository not found")
+	ErrNonFastForward  = errors.New("non-fast-forward")
+	ErrLocalChanges    = errors.New("local changes would be overwritten")
+	ErrLockFile        = errors.New("lock file exists")
+)
+
+// Causes lists all known causes, in order of classification precedence.
+var Causes = []error{
+	ErrLockFile,
+	ErrAuthFailed,
+	ErrHostUnreachable,
+	ErrRepoNotFound,
+	ErrLocalChanges,
+	ErrNonFastForward,
+}
+
+// causePatterns are lower-case stderr fragments identifying a cause.
+var causePatterns = map[error][]string{
+	ErrLockFile: {
+		".lock': file exists",
+		"another git process seems to be running",
+	},
+	ErrAuthFailed: {
+		"permission denied (publickey",
+		"authentication failed",
+		"could not read username",
+		"could not read password",
+		"terminal prompts disabled",
+		"http basic: access denied",
+		"invalid username or password",
+		"the requested url returned error: 401",
+		"the requested url returned error: 403",
+	},
+	ErrHostUnreachable: {
+		"could not resolve host",
+		"could not resolve hostname",
+		"connection refused",
+		"connection timed out",
+		"operation timed out",
+		"network is unreachable",
+		"no route to host",
+		"connection reset by peer",
+	},
+	ErrRepoNotFound: {
+		"repository not found",
+		"does not appear to be a git repository",
+		"the requested url returned error: 404",
+		"the project you were looking for could not be found",
+	},
+	ErrLocalChanges: {
+		"would be overwritten by",
+		"please commit your changes or stash them",
+		"you have unstaged changes",
+		"your index contains uncommitted changes",
+	},
+	ErrNonFastForward: {
+		"not possible to fast-forward",
+		"non-fast-forward",
+		"diverging branches",
+		"[rejected]",
+		"fetch first",
+	},
+}
+
+// Error is a failed git execution, classified by its stderr output.
+type Error struct {
+	// Cause is one of the known causes, or nil if unclassified.
+	Cause error
+	// Stderr is the raw git error output.
+	Stderr string
+	// Err is the underlying execution error.
+	Err error
+}
+
+// newError classifies a failed git execution by its stderr output.
+func newError(stderr string, err error) *Error {
+	return &Error{
+		Cause:  classify(stderr),
+		Stderr: cleanOutput([]byte(stderr)),
+		Err:    err,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := e.message()
+	if msg == "" {
+		msg = e.Err.Error()
+	}
+	if e.Cause == nil {
+		return msg
+	}
+	return e.Cause.Error() + ": " + msg
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Cause, e.Err}
+}
+
+// message returns the most relevant line of git's error output.
+func (e *Error) message() string {
+	lines := strings.Split(e.Stderr, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if msg, ok := strings.CutPrefix(line, "fatal: "); ok {
+			return msg
+		}
+		if msg, ok := strings.CutPrefix(line, "error: "); ok {
+			return msg
+		}
+	}
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// Cause returns the known cause of an error, or nil.
+func Cause(err error) error {
+	for _, cause := range Causes {
+		if errors.Is(err, cause) {
+			return cause
+		}
+	}
+	return nil
+}
+
+// classify returns the known cause of git's error output, or nil.
+func classify(stderr string) error {
+	stderr = strings.ToLower(stderr)
+	for _, cause := range Causes {
+		for _, pattern := range causePatterns[cause] {
+			if strings.Contains(stderr, pattern) {
+				return cause
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package git
+
+import "os/exec"
+
+// setProcessGroup is a no-op where process groups are not supported.
+func setProcessGroup(_ *exec.Cmd) {}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrNotSupported is returned by backends for unimplemented operations.
+var ErrNotSupported = errors.New("operation not supported by git backend")
+
+// Git is the set of git operations used by gits. Shell runs the git
+// executable, while GoGit is a pure go-git implementation.
+type Git interface {
+	// Batch returns a copy of the client for unattended operations across
+	// many repositories, where git must never wait for user input.
+	Batch() Git
+
+	Open(path string) (Repository, error)
+	IsRepo(path string) bool
+
+	Cloner
+	RemoteManager
+	Syncer
+	Committer
+	Tagger
+	BranchManager
+	WorktreeManager
+	HistoryReader
+	Inspector
+}
+
+// Cloner creates local copies of remote repositories.
+type Cloner interface {
+	Clone(ctx context.Context, remote, path string, opts CloneOptions) (string, error)
+	Mirror(ctx context.Context, remote, path string) (string, error)
+	UpdateMirror(ctx context.Context, path string) (string, error)
+	Bundle(ctx context.Context, path, file string, refs []string) (string, error)
+}
+
+// RemoteManager reads and configures repository remotes.
+type RemoteManager interface {
+	Remote(ctx context.Context, path string) (string, error)
+	AddRemote(ctx context.Context, path, name, url string) error
+	SetRemoteURL(ctx context.Context, path, name, url string) error
+	DefaultBranch(ctx context.Context, path, remote string) (string, error)
+}
+
+// Syncer exchanges commits with remotes, and updates submodules.
+type Syncer interface {
+	Fetch(ctx context.Context, path string) (string, error)
+	Pull(ctx context.Context, path string, opts PullOptions) (PullResult, error)
+	Push(ctx context.Context, path string, opts PushOptions) (string, error)
+	FastForward(ctx context.Context, path, branch, target string) (string, error)
+	UpdateSubmodules(ctx context.Context, path string) (string, error)
+}
+
+// Committer records working tree changes.
+type Committer interface {
+	ChangedFiles(ctx context.Context, path string) ([]FileStat, error)
+	CommitAll(ctx context.Context, path, message string) (string, error)
+	ApplyPatch(ctx context.Context, path, patch string) (string, error)
+}
+
+// Tagger creates and publishes tags.
+type Tagger interface {
+	CreateTag(ctx context.Context, path, name string, opts TagOptions) (string, error)
+	TagCommit(ctx context.Context, path, name string) (string, error)
+	PushTag(ctx context.Context, path, remote, name string) (string, error)
+}
+
+// BranchManager lists and manages local branches.
+type BranchManager interface {
+	LocalBranches(ctx context.Context, path, target string) ([]LocalBranch, error)
+	UnpushedBranches(ctx context.Context, path string) ([]UnpushedBranch, error)
+	CreateBranch(ctx context.Context, path, name, base string) (string, error)
+	DeleteBranch(ctx context.Context, path, name string, force bool) (string, error)
+	RenameBranch(ctx context.Context, path, name, newName string) (string, error)
+}
+
+// WorktreeManager manages linked worktrees.
+type WorktreeManager interface {
+	AddWorktree(ctx context.Context, path, dest, branch, base string) (string, error)
+	Worktrees(ctx context.Context, path string) ([]Worktree, error)
+	RemoveWorktree(ctx context.Context, path, dest string) (string, error)
+	RepairWorktrees(ctx context.Context, path string) (string, error)
+}
+
+// HistoryReader reads commits, refs and file contents.
+type HistoryReader interface {
+	Log(ctx context.Context, path, ref string) (string, error)
+	CommitDates(ctx context.Context, path, branch string, days int) ([]string, error)
+	Commits(ctx context.Context, path string, opts LogOptions) ([]Commit, error)
+	UserEmail(ctx context.Context, path string) (string, error)
+	Refs(ctx context.Context, path string) ([]string, error)
+	Grep(ctx context.Context, path string, opts GrepOptions) ([]GrepMatch, error)
+}
+
+// Inspector reports the state of a working tree.
+type Inspector interface {
+	Status(ctx context.Context, path string) (Status, error)
+	Describe(ctx context.Context, path string) (string, error)
+	CurrentPosition(ctx context.Context, path string) (string, error)
+	Diff(ctx context.Context, path, branch, target string) (int, int, error)
+	Submodules(ctx context.Context, path string) ([]Submodule, error)
+}
+
+// NewGit returns a git client, running the git executable if found in PATH,
+// otherwise falls back to a pure go-git implementation.
+func NewGit() (Git, error) {
+	shell, err := NewShell()
+	if err != nil {
+		log.Debugf("using go-git: %s", err)
+		return NewGoGit(), nil
+	}
+	return shell, nil
+}
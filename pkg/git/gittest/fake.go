@@ -0,0 +1,404 @@
+// Package gittest provides an in-memory git client for handler tests.
+package gittest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rafi/gits/pkg/git"
+)
+
+// Repo is the state of an in-memory repository.
+type Repo struct {
+	// Head is the checked out branch.
+	Head string
+	// Branches are local branch names.
+	Branches []string
+	// RemoteBranches are remote branch names, e.g. "origin/main".
+	RemoteBranches []string
+	// Remotes are remote URLs by name.
+	Remotes map[string]string
+	// Upstreams are upstream branch names by local branch.
+	Upstreams map[string]string
+	// DefaultBranch is the default branch of every remote.
+	DefaultBranch string
+	// Unmerged are branches that can only be deleted when forced.
+	Unmerged []string
+}
+
+// Fake is an in-memory git client. Repositories are keyed by path, and
+// operations that aren't modeled return git.ErrNotSupported.
+type Fake struct {
+	mu    sync.Mutex
+	repos map[string]*Repo
+}
+
+var _ git.Git = (*Fake)(nil)
+
+// New returns a fake git client with repos keyed by path.
+func New(repos map[string]*Repo) *Fake {
+	if repos == nil {
+		repos = map[string]*Repo{}
+	}
+	return &Fake{repos: repos}
+}
+
+// Repo returns the repository at path, for assertions.
+func (f *Fake) Repo(path string) *Repo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.repos[path]
+}
+
+// do runs fn on the repository at path while holding the lock.
+func (f *Fake) do(path string, fn func(*Repo) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	repo, ok := f.repos[path]
+	if !ok {
+		return fmt.Errorf("not a git repository: %s", path)
+	}
+	return fn(repo)
+}
+
+// Batch returns the same client, the fake never prompts for input.
+func (f *Fake) Batch() git.Git {
+	return f
+}
+
+// Open returns a repository client.
+func (f *Fake) Open(path string) (git.Repository, error) {
+	if !f.IsRepo(path) {
+		return nil, fmt.Errorf("failed to open git repository: %s", path)
+	}
+	return &repository{fake: f, path: path}, nil
+}
+
+// IsRepo checks if a repository exists at path.
+func (f *Fake) IsRepo(path string) bool {
+	return f.do(path, func(*Repo) error { return nil }) == nil
+}
+
+// Remote returns the URL of origin.
+func (f *Fake) Remote(_ context.Context, path string) (url string, err error) {
+	err = f.do(path, func(r *Repo) error {
+		url = r.Remotes["origin"]
+		return nil
+	})
+	return url, err
+}
+
+// AddRemote adds a remote.
+func (f *Fake) AddRemote(_ context.Context, path, name, url string) error {
+	return f.do(path, func(r *Repo) error {
+		if _, ok := r.Remotes[name]; ok {
+			return fmt.Errorf("remote %s already exists", name)
+		}
+		if r.Remotes == nil {
+			r.Remotes = map[string]string{}
+		}
+		r.Remotes[name] = url
+		return nil
+	})
+}
+
+// SetRemoteURL changes the URL of an existing remote.
+func (f *Fake) SetRemoteURL(_ context.Context, path, name, url string) error {
+	return f.do(path, func(r *Repo) error {
+		if _, ok := r.Remotes[name]; !ok {
+			return fmt.Errorf("no such remote %s", name)
+		}
+		r.Remotes[name] = url
+		return nil
+	})
+}
+
+// DefaultBranch returns the default branch of the repository.
+func (f *Fake) DefaultBranch(_ context.Context, path, remote string) (branch string, err error) {
+	err = f.do(path, func(r *Repo) error {
+		if _, ok := r.Remotes[remote]; !ok {
+			return fmt.Errorf("no such remote %s", remote)
+		}
+		branch = r.DefaultBranch
+		return nil
+	})
+	return branch, err
+}
+
+// LocalBranches returns local branches with their upstream. Target is
+// ignored, branches are merged unless listed in Unmerged.
+func (f *Fake) LocalBranches(_ context.Context, path, _ string) (branches []git.LocalBranch, err error) {
+	err = f.do(path, func(r *Repo) error {
+		for _, name := range r.Branches {
+			branches = append(branches, git.LocalBranch{
+				Name:     name,
+				Upstream: r.Upstreams[name],
+				Merged:   !slices.Contains(r.Unmerged, name),
+			})
+		}
+		return nil
+	})
+	return branches, err
+}
+
+// CreateBranch creates a branch from a local or remote base branch.
+func (f *Fake) CreateBranch(_ context.Context, path, name, base string) (string, error) {
+	return "", f.do(path, func(r *Repo) error {
+		if slices.Contains(r.Branches, name) {
+			return fmt.Errorf("a branch named '%s' already exists", name)
+		}
+		if !slices.Contains(r.Branches, base) && !slices.Contains(r.RemoteBranches, base) {
+			return fmt.Errorf("not a valid object name: '%s'", base)
+		}
+		r.Branches = append(r.Branches, name)
+		return nil
+	})
+}
+
+// DeleteBranch deletes a branch, refusing unmerged branches unless forced.
+func (f *Fake) DeleteBranch(_ context.Context, path, name string, force bool) (string, error) {
+	return "", f.do(path, func(r *Repo) error {
+		idx := slices.Index(r.Branches, name)
+		switch {
+		case idx < 0:
+			return fmt.Errorf("branch '%s' not found", name)
+		case name == r.Head:
+			return fmt.Errorf("cannot delete branch '%s' checked out", name)
+		case !force && slices.Contains(r.Unmerged, name):
+			return fmt.Errorf("the branch '%s' is not fully merged", name)
+		}
+		r.Branches = slices.Delete(r.Branches, idx, idx+1)
+		delete(r.Upstreams, name)
+		return nil
+	})
+}
+
+// RenameBranch renames a branch, refusing to overwrite an existing one.
+func (f *Fake) RenameBranch(_ context.Context, path, name, newName string) (string, error) {
+	return "", f.do(path, func(r *Repo) error {
+		idx := slices.Index(r.Branches, name)
+		switch {
+		case idx < 0:
+			return fmt.Errorf("branch '%s' not found", name)
+		case slices.Contains(r.Branches, newName):
+			return fmt.Errorf("a branch named '%s' already exists", newName)
+		}
+		r.Branches[idx] = newName
+		if r.Head == name {
+			r.Head = newName
+		}
+		return nil
+	})
+}
+
+// Status returns a clean status of the checked out branch.
+func (f *Fake) Status(_ context.Context, path string) (status git.Status, err error) {
+	err = f.do(path, func(r *Repo) error {
+		status.Branch = r.Head
+		status.Upstream = r.Upstreams[r.Head]
+		return nil
+	})
+	return status, err
+}
+
+func (f *Fake) Clone(context.Context, string, string, git.CloneOptions) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Mirror(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) UpdateMirror(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Bundle(context.Context, string, string, []string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+// Fetch succeeds without changes, remote branches are set by tests.
+func (f *Fake) Fetch(_ context.Context, path string) (string, error) {
+	return "", f.do(path, func(*Repo) error { return nil })
+}
+
+func (f *Fake) Pull(context.Context, string, git.PullOptions) (git.PullResult, error) {
+	return git.PullResult{}, git.ErrNotSupported
+}
+
+func (f *Fake) Push(context.Context, string, git.PushOptions) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) FastForward(context.Context, string, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) UpdateSubmodules(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) ChangedFiles(context.Context, string) ([]git.FileStat, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) CommitAll(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) ApplyPatch(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) CreateTag(context.Context, string, string, git.TagOptions) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) TagCommit(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) PushTag(context.Context, string, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) UnpushedBranches(context.Context, string) ([]git.UnpushedBranch, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) AddWorktree(context.Context, string, string, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Worktrees(context.Context, string) ([]git.Worktree, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) RemoveWorktree(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) RepairWorktrees(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Log(context.Context, string, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) CommitDates(context.Context, string, string, int) ([]string, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) Commits(context.Context, string, git.LogOptions) ([]git.Commit, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) UserEmail(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Refs(context.Context, string) ([]string, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) Grep(context.Context, string, git.GrepOptions) ([]git.GrepMatch, error) {
+	return nil, git.ErrNotSupported
+}
+
+func (f *Fake) Describe(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) CurrentPosition(context.Context, string) (string, error) {
+	return "", git.ErrNotSupported
+}
+
+func (f *Fake) Diff(context.Context, string, string, string) (int, int, error) {
+	return 0, 0, git.ErrNotSupported
+}
+
+func (f *Fake) Submodules(context.Context, string) ([]git.Submodule, error) {
+	return nil, git.ErrNotSupported
+}
+
+// repository is an in-memory repository client.
+type repository struct {
+	fake *Fake
+	path string
+}
+
+func (r *repository) Branches(_ context.Context) (branches []string, err error) {
+	err = r.fake.do(r.path, func(repo *Repo) error {
+		branches = append(slices.Clone(repo.Branches), repo.RemoteBranches...)
+		return nil
+	})
+	return branches, err
+}
+
+func (r *repository) CurrentBranch(_ context.Context) (branch string, err error) {
+	err = r.fake.do(r.path, func(repo *Repo) error {
+		branch = repo.Head
+		return nil
+	})
+	return branch, err
+}
+
+func (r *repository) IsLocalBranch(_ context.Context, branch string) (found bool) {
+	_ = r.fake.do(r.path, func(repo *Repo) error {
+		found = slices.Contains(repo.Branches, branch)
+		return nil
+	})
+	return found
+}
+
+func (r *repository) IsRemoteBranch(_ context.Context, remote, branch string) (found bool) {
+	_ = r.fake.do(r.path, func(repo *Repo) error {
+		found = slices.Contains(repo.RemoteBranches, remote+"/"+branch)
+		return nil
+	})
+	return found
+}
+
+func (r *repository) Remotes(_ context.Context) (remotes []string, err error) {
+	err = r.fake.do(r.path, func(repo *Repo) error {
+		for name := range repo.Remotes {
+			remotes = append(remotes, name)
+		}
+		slices.Sort(remotes)
+		return nil
+	})
+	return remotes, err
+}
+
+func (r *repository) Checkout(_ context.Context, branch string) error {
+	return r.fake.do(r.path, func(repo *Repo) error {
+		name := branch
+		for remote := range repo.Remotes {
+			name = strings.TrimPrefix(name, remote+"/")
+		}
+		switch {
+		case slices.Contains(repo.Branches, name):
+		case slices.Contains(repo.RemoteBranches, branch):
+			repo.Branches = append(repo.Branches, name)
+		default:
+			return fmt.Errorf("invalid reference: %s", branch)
+		}
+		repo.Head = name
+		return nil
+	})
+}
+
+func (r *repository) Upstream(_ context.Context, branch string) (upstream string, err error) {
+	err = r.fake.do(r.path, func(repo *Repo) error {
+		upstream = repo.Upstreams[branch]
+		if upstream == "" {
+			return git.ErrNoUpstream
+		}
+		return nil
+	})
+	return upstream, err
+}
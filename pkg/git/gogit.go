@@ -0,0 +1,1624 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v6"
+	"github.com/go-git/go-billy/v6/osfs"
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/cache"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v6/plumbing/storer"
+	"github.com/go-git/go-git/v6/plumbing/transport"
+	"github.com/go-git/go-git/v6/storage/filesystem"
+	"github.com/go-git/go-git/v6/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const logLimit = 15
+
+// GoGit is a pure go-git client, used when the git executable is missing.
+type GoGit struct{}
+
+// NewGoGit returns a pure go-git client.
+func NewGoGit() *GoGit {
+	return &GoGit{}
+}
+
+// Batch returns the same client, go-git never prompts for input.
+func (g *GoGit) Batch() Git {
+	return g
+}
+
+// Clone clones repository to filesystem.
+// Borrowing objects from a reference repository is not supported.
+func (g *GoGit) Clone(ctx context.Context, remote string, path string, opts CloneOptions) (string, error) {
+	if opts.Reference != "" {
+		return "", fmt.Errorf("unable to clone with reference: %w", ErrNotSupported)
+	}
+	if err := prepareClonePath(path); err != nil {
+		return "", err
+	}
+	cloneOpts := &git.CloneOptions{
+		URL:          remote,
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+		Filter:       packp.Filter(opts.Filter),
+	}
+	if opts.Branch != "" {
+		cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(opts.Branch)
+	}
+	_, err := git.PlainCloneContext(ctx, path, cloneOpts)
+	if err != nil {
+		// Unlike git, go-git leaves a partial clone behind.
+		_ = os.RemoveAll(path)
+		return "", fmt.Errorf("unable to clone: %w", goGitError(ctx, err))
+	}
+	return fmt.Sprintf("Cloned into '%s'", filepath.Base(path)), nil
+}
+
+// Open returns a git repository client.
+func (g *GoGit) Open(path string) (Repository, error) {
+	repo, err := openRepository(path)
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// IsRepo checks if the directory is a git repository.
+func (g *GoGit) IsRepo(path string) bool {
+	_, err := git.PlainOpen(path)
+	return err == nil
+}
+
+// Remote returns the URL of origin, or of the first remote.
+func (g *GoGit) Remote(_ context.Context, path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to get remote URL: %w", err)
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return "", fmt.Errorf("unable to get remote URL: %w", err)
+	}
+	url := ""
+	for _, remote := range remotes {
+		cfg := remote.Config()
+		if len(cfg.URLs) == 0 {
+			continue
+		}
+		if url == "" || cfg.Name == git.DefaultRemoteName {
+			url = cfg.URLs[0]
+		}
+	}
+	if url == "" {
+		return "", errors.New("unable to get remote URL: no remotes")
+	}
+	return url, nil
+}
+
+// Fetch fetches all remotes and tags, and prunes deleted branches.
+func (g *GoGit) Fetch(ctx context.Context, path string) (string, error) {
+	repo, err := openRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("error during fetch: %w", err)
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return "", fmt.Errorf("error during fetch: %w", err)
+	}
+	for _, remote := range remotes {
+		err := repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: remote.Config().Name,
+			Tags:       git.AllTags,
+			Force:      true,
+			Prune:      true,
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return "", fmt.Errorf("error during fetch: %w", goGitError(ctx, err))
+		}
+	}
+	return "", nil
+}
+
+// Pull fetches from remote and fast-forwards the current branch. Rebase,
+// merge and autostash are not supported by go-git.
+func (g *GoGit) Pull(ctx context.Context, path string, opts PullOptions) (PullResult, error) {
+	result := PullResult{Strategy: PullFastForward}
+	if (opts.Strategy != "" && opts.Strategy != PullFastForward) || opts.Autostash {
+		return result, fmt.Errorf("error during pull: %w", ErrNotSupported)
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return result, fmt.Errorf("error during pull: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return result, fmt.Errorf("error during pull: %w", err)
+	}
+	err = w.PullContext(ctx, &git.PullOptions{RemoteName: git.DefaultRemoteName})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		result.Output = "Already up to date."
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("error during pull: %w", goGitError(ctx, err))
+	}
+	result.Output = "Fast-forward"
+	return result, nil
+}
+
+// Push pushes the current branch to its upstream, or Branch to a remote
+// branch of the same name.
+func (g *GoGit) Push(ctx context.Context, path string, opts PushOptions) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("error during push: %w", err)
+	}
+
+	local := plumbing.NewBranchReferenceName(opts.Branch)
+	remote, merge := opts.Remote, local
+	if opts.Branch == "" {
+		head, err := repo.Head()
+		if err != nil {
+			return "", fmt.Errorf("error during push: %w", err)
+		}
+		branch, err := repo.Branch(head.Name().Short())
+		if !head.Name().IsBranch() || err != nil || branch.Remote == "" || branch.Merge == "" {
+			return "", fmt.Errorf("error during push: %w", ErrNoUpstream)
+		}
+		local, remote, merge = head.Name(), branch.Remote, branch.Merge
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s", local, merge))
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{refSpec},
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "Everything up-to-date", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error during push: %w", goGitError(ctx, err))
+	}
+
+	if opts.SetUpstream {
+		cfg, err := repo.Config()
+		if err != nil {
+			return "", fmt.Errorf("unable to set upstream: %w", err)
+		}
+		cfg.Branches[local.Short()] = &config.Branch{
+			Name:   local.Short(),
+			Remote: remote,
+			Merge:  merge,
+		}
+		if err := repo.SetConfig(cfg); err != nil {
+			return "", fmt.Errorf("unable to set upstream: %w", err)
+		}
+	}
+	return fmt.Sprintf("%s -> %s", local.Short(), merge.Short()), nil
+}
+
+// AddRemote adds a remote to a repository.
+func (g *GoGit) AddRemote(_ context.Context, path, name, url string) error {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("unable to add remote: %w", err)
+	}
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: name,
+		URLs: []string{url},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add remote: %w", err)
+	}
+	return nil
+}
+
+// DefaultBranch returns the default branch of a remote, from its local HEAD
+// reference if set, otherwise by querying the remote.
+func (g *GoGit) DefaultBranch(ctx context.Context, path, remoteName string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to find default branch: %w", err)
+	}
+	headRef := plumbing.NewRemoteHEADReferenceName(remoteName)
+	if ref, err := repo.Reference(headRef, false); err == nil {
+		prefix := plumbing.NewRemoteReferenceName(remoteName, "").String()
+		return strings.TrimPrefix(ref.Target().String(), prefix), nil
+	}
+
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return "", fmt.Errorf("unable to find default branch: %w", err)
+	}
+	refs, err := remote.ListContext(ctx, &git.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to find default branch: %w", goGitError(ctx, err))
+	}
+	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD && ref.Type() == plumbing.SymbolicReference {
+			return ref.Target().Short(), nil
+		}
+	}
+	return "", fmt.Errorf("unable to find default branch of %s", remoteName)
+}
+
+// FastForward fast-forwards a local branch to target, updating the working
+// tree if the branch is checked out.
+func (g *GoGit) FastForward(ctx context.Context, path, branch, target string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, targetHash, err := resolve(path, target)
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	ref, err := repo.Reference(branchRef, true)
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	current, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	targetCommit, err := repo.CommitObject(targetHash)
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	if ok, err := current.IsAncestor(targetCommit); err != nil || !ok {
+		return "", fmt.Errorf("unable to fast-forward: %w", git.ErrNonFastForwardUpdate)
+	}
+	updating := fmt.Sprintf(
+		"Updating %s..%s", ref.Hash().String()[:7], targetHash.String()[:7],
+	)
+
+	head, err := repo.Head()
+	if err != nil || head.Name() != branchRef {
+		newRef := plumbing.NewHashReference(branchRef, targetHash)
+		if err := repo.Storer.CheckAndSetReference(newRef, ref); err != nil {
+			return "", fmt.Errorf("unable to fast-forward: %w", err)
+		}
+		return updating, nil
+	}
+
+	// Branch is checked out, refuse to touch local changes.
+	w, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	for _, file := range status {
+		if file.Staging != git.Untracked || file.Worktree != git.Untracked {
+			if file.Staging != git.Unmodified || file.Worktree != git.Unmodified {
+				return "", errors.New("unable to fast-forward: local changes would be overwritten")
+			}
+		}
+	}
+	err = w.Reset(&git.ResetOptions{Commit: targetHash, Mode: git.MergeReset})
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	return updating + "\nFast-forward", nil
+}
+
+// Grep searches tracked files of a ref. go-git can't search the working
+// tree, HEAD is searched instead.
+func (g *GoGit) Grep(ctx context.Context, path string, opts GrepOptions) ([]GrepMatch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	pattern, err := regexp.Compile(opts.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to grep: %w", err)
+	}
+	repo, hash, err := resolve(path, opts.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to grep: %w", err)
+	}
+	results, err := repo.Grep(&git.GrepOptions{
+		Patterns:   []*regexp.Regexp{pattern},
+		CommitHash: hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to grep: %w", err)
+	}
+
+	matches := make([]GrepMatch, 0, len(results))
+	seen := map[string]bool{}
+	for _, result := range results {
+		if opts.FilesOnly {
+			if seen[result.FileName] {
+				continue
+			}
+			seen[result.FileName] = true
+			matches = append(matches, GrepMatch{File: result.FileName})
+			continue
+		}
+		matches = append(matches, GrepMatch{
+			File: result.FileName,
+			Line: result.LineNumber,
+			Text: result.Content,
+		})
+	}
+	return matches, nil
+}
+
+// ApplyPatch is not supported by go-git.
+func (g *GoGit) ApplyPatch(_ context.Context, _, _ string) (string, error) {
+	return "", fmt.Errorf("unable to apply patch: %w", ErrNotSupported)
+}
+
+// ChangedFiles returns staged, unstaged and untracked files, relative to HEAD.
+func (g *GoGit) ChangedFiles(ctx context.Context, path string) ([]FileStat, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get diff: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get diff: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get diff: %w", err)
+	}
+	var tree *object.Tree
+	if head, err := repo.Head(); err == nil {
+		if commit, err := repo.CommitObject(head.Hash()); err == nil {
+			tree, _ = commit.Tree()
+		}
+	}
+
+	files := []FileStat{}
+	for name, file := range status {
+		if file.Staging == git.Unmodified && file.Worktree == git.Unmodified {
+			continue
+		}
+		stat := FileStat{Path: name}
+		if file.Worktree == git.Untracked {
+			stat.Untracked = true
+			files = append(files, stat)
+			continue
+		}
+		src, dst := "", ""
+		if tree != nil {
+			if f, err := tree.File(name); err == nil {
+				if stat.Binary, err = f.IsBinary(); err == nil && !stat.Binary {
+					src, _ = f.Contents()
+				}
+			}
+		}
+		if content, err := os.ReadFile(filepath.Join(path, name)); err == nil {
+			stat.Binary = stat.Binary || bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+			dst = string(content)
+		}
+		if !stat.Binary {
+			for _, d := range diff.Do(src, dst) {
+				lines := strings.Count(d.Text, "\n")
+				if !strings.HasSuffix(d.Text, "\n") {
+					lines++
+				}
+				switch d.Type {
+				case diffmatchpatch.DiffInsert:
+					stat.Added += lines
+				case diffmatchpatch.DiffDelete:
+					stat.Deleted += lines
+				}
+			}
+		}
+		files = append(files, stat)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// CommitAll stages all changes, including untracked files, and commits them.
+func (g *GoGit) CommitAll(ctx context.Context, path, message string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to commit: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("unable to commit: %w", err)
+	}
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return "", fmt.Errorf("unable to stage changes: %w", err)
+	}
+	hash, err := w.Commit(message, &git.CommitOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to commit: %w", err)
+	}
+	return hash.String(), nil
+}
+
+// CreateTag creates an annotated tag on HEAD. Signed tags are not supported
+// by go-git.
+func (g *GoGit) CreateTag(ctx context.Context, path, name string, opts TagOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if opts.Sign {
+		return "", fmt.Errorf("unable to create signed tag: %w", ErrNotSupported)
+	}
+	repo, hash, err := resolve(path, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("unable to create tag: %w", err)
+	}
+	_, err = repo.CreateTag(name, hash, &git.CreateTagOptions{Message: opts.Message})
+	if err != nil {
+		return "", fmt.Errorf("unable to create tag: %w", err)
+	}
+	return "", nil
+}
+
+// TagCommit returns the commit hash a tag points to, or an empty string if
+// the tag does not exist.
+func (g *GoGit) TagCommit(ctx context.Context, path, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to find tag: %w", err)
+	}
+	ref, err := repo.Tag(name)
+	if errors.Is(err, git.ErrTagNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to find tag: %w", err)
+	}
+	commit, err := peelCommit(repo, ref.Hash())
+	if err != nil {
+		return "", fmt.Errorf("unable to find tag: %w", err)
+	}
+	return commit.Hash.String(), nil
+}
+
+// PushTag pushes a single tag to remote.
+func (g *GoGit) PushTag(ctx context.Context, path, remote, name string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to push tag: %w", err)
+	}
+	ref := plumbing.NewTagReferenceName(name)
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{config.RefSpec(ref + ":" + ref)},
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "Everything up-to-date", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to push tag: %w", goGitError(ctx, err))
+	}
+	return fmt.Sprintf("%s -> %s", name, name), nil
+}
+
+// LocalBranches returns local branches, and whether they are merged into
+// target or their upstream is gone.
+func (g *GoGit) LocalBranches(ctx context.Context, path, target string) ([]LocalBranch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, targetHash, err := resolve(path, target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	targetCommit, err := repo.CommitObject(targetHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	iter, err := repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+
+	mainline, err := firstParents(ctx, repo, targetCommit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list target history: %w", err)
+	}
+
+	branches := []LocalBranch{}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		branch := LocalBranch{Name: ref.Name().Short(), Empty: mainline[ref.Hash()]}
+		if commit, err := repo.CommitObject(ref.Hash()); err == nil {
+			branch.Merged = branch.Empty
+			if !branch.Merged {
+				branch.Merged, _ = commit.IsAncestor(targetCommit)
+			}
+		}
+		if bc, found := cfg.Branches[branch.Name]; found && bc.Remote != "" && bc.Merge != "" {
+			upstream := bc.Merge
+			if bc.Remote != "." {
+				upstream = plumbing.NewRemoteReferenceName(bc.Remote, bc.Merge.Short())
+			}
+			branch.Upstream = upstream.Short()
+			if _, err := repo.Reference(upstream, false); err != nil {
+				branch.Gone = true
+			}
+		}
+		branches = append(branches, branch)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].Name < branches[j].Name
+	})
+	return branches, nil
+}
+
+// firstParents returns the hashes of the first-parent history of commit.
+func firstParents(
+	ctx context.Context,
+	repo *git.Repository,
+	commit *object.Commit,
+) (map[plumbing.Hash]bool, error) {
+	hashes := map[plumbing.Hash]bool{commit.Hash: true}
+	for len(commit.ParentHashes) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		parent, err := repo.CommitObject(commit.ParentHashes[0])
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			// Shallow clones miss commits beyond their depth.
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		hashes[parent.Hash] = true
+		commit = parent
+	}
+	return hashes, nil
+}
+
+// UnpushedBranches returns local branches with commits not found on any
+// remote, and the number of those commits. A detached HEAD is included if it
+// has commits not found on any remote or branch.
+func (g *GoGit) UnpushedBranches(ctx context.Context, path string) ([]UnpushedBranch, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	remoteHeads := []plumbing.Hash{}
+	localRefs := []*plumbing.Reference{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		switch {
+		case ref.Type() != plumbing.HashReference:
+		case ref.Name().IsRemote():
+			remoteHeads = append(remoteHeads, ref.Hash())
+		case ref.Name().IsBranch():
+			localRefs = append(localRefs, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+
+	// Collect all commits reachable from remote branches.
+	pushed := map[plumbing.Hash]bool{}
+	for _, hash := range remoteHeads {
+		if err := walkCommits(ctx, repo, hash, pushed, nil); err != nil {
+			return nil, fmt.Errorf("unable to count unpushed commits: %w", err)
+		}
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	branches := []UnpushedBranch{}
+	for _, ref := range localRefs {
+		unpushed := map[plumbing.Hash]bool{}
+		if err := walkCommits(ctx, repo, ref.Hash(), unpushed, pushed); err != nil {
+			return nil, fmt.Errorf("unable to count unpushed commits: %w", err)
+		}
+		if len(unpushed) == 0 {
+			continue
+		}
+		branch := UnpushedBranch{Name: ref.Name().Short(), Commits: len(unpushed)}
+		if bc, found := cfg.Branches[branch.Name]; found && bc.Remote != "" && bc.Merge != "" {
+			branch.Upstream = bc.Remote + "/" + bc.Merge.Short()
+		}
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].Name < branches[j].Name
+	})
+
+	// Commits on a detached HEAD are only referenced by the reflog.
+	head, err := repo.Head()
+	if err != nil || head.Name() != plumbing.HEAD {
+		return branches, nil
+	}
+	for _, ref := range localRefs {
+		if err := walkCommits(ctx, repo, ref.Hash(), pushed, nil); err != nil {
+			return nil, fmt.Errorf("unable to count detached commits: %w", err)
+		}
+	}
+	detached := map[plumbing.Hash]bool{}
+	if err := walkCommits(ctx, repo, head.Hash(), detached, pushed); err != nil {
+		return nil, fmt.Errorf("unable to count detached commits: %w", err)
+	}
+	if len(detached) > 0 {
+		branches = append(branches, UnpushedBranch{Name: "HEAD", Commits: len(detached), Detached: true})
+	}
+	return branches, nil
+}
+
+// walkCommits adds commits reachable from hash to seen, stopping at commits
+// already seen or in stop.
+func walkCommits(
+	ctx context.Context,
+	repo *git.Repository,
+	hash plumbing.Hash,
+	seen, stop map[plumbing.Hash]bool,
+) error {
+	queue := []plumbing.Hash{hash}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hash, queue = queue[0], queue[1:]
+		if seen[hash] || stop[hash] {
+			continue
+		}
+		commit, err := repo.CommitObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			// Shallow clones miss commits beyond their depth.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		seen[hash] = true
+		queue = append(queue, commit.ParentHashes...)
+	}
+	return nil
+}
+
+// CreateBranch creates a branch from base, without checking it out.
+func (g *GoGit) CreateBranch(ctx context.Context, path, name, base string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, hash, err := resolve(path, base)
+	if err != nil {
+		return "", fmt.Errorf("unable to create branch: %w", err)
+	}
+	branchRef := plumbing.NewBranchReferenceName(name)
+	if _, err := repo.Reference(branchRef, false); err == nil {
+		return "", fmt.Errorf("unable to create branch: a branch named '%s' already exists", name)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, hash)); err != nil {
+		return "", fmt.Errorf("unable to create branch: %w", err)
+	}
+	return "", nil
+}
+
+// DeleteBranch deletes a branch, refusing branches not merged into their
+// upstream or HEAD, unless forced.
+func (g *GoGit) DeleteBranch(ctx context.Context, path, name string, force bool) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, err := openRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to delete branch: %w", err)
+	}
+	branchRef := plumbing.NewBranchReferenceName(name)
+	ref, err := repo.Reference(branchRef, false)
+	if err != nil {
+		return "", fmt.Errorf("unable to delete branch: branch '%s' not found", name)
+	}
+	if head, err := repo.Head(); err == nil && head.Name() == branchRef {
+		return "", fmt.Errorf("unable to delete branch: cannot delete checked out branch '%s'", name)
+	}
+
+	if !force {
+		// Merged into upstream if set, otherwise into HEAD.
+		target := "HEAD"
+		if branch, err := repo.Branch(name); err == nil && branch.Remote != "" && branch.Merge != "" {
+			target = plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short()).String()
+		}
+		targetHash, err := repo.ResolveRevision(plumbing.Revision(target))
+		if err != nil {
+			return "", fmt.Errorf("unable to delete branch: %w", err)
+		}
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return "", fmt.Errorf("unable to delete branch: %w", err)
+		}
+		targetCommit, err := repo.CommitObject(*targetHash)
+		if err != nil {
+			return "", fmt.Errorf("unable to delete branch: %w", err)
+		}
+		if merged, err := commit.IsAncestor(targetCommit); err != nil || !merged {
+			return "", fmt.Errorf("unable to delete branch: the branch '%s' is not fully merged", name)
+		}
+	}
+
+	if err := repo.Storer.RemoveReference(branchRef); err != nil {
+		return "", fmt.Errorf("unable to delete branch: %w", err)
+	}
+	if err := repo.DeleteBranch(name); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		return "", fmt.Errorf("unable to delete branch config: %w", err)
+	}
+	return fmt.Sprintf("Deleted branch %s (was %s).", name, ref.Hash().String()[:7]), nil
+}
+
+// RenameBranch renames a branch, refusing to overwrite an existing one.
+func (g *GoGit) RenameBranch(ctx context.Context, path, name, newName string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, err := openRepo(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to rename branch: %w", err)
+	}
+	oldRef := plumbing.NewBranchReferenceName(name)
+	newRef := plumbing.NewBranchReferenceName(newName)
+	ref, err := repo.Reference(oldRef, false)
+	if err != nil {
+		return "", fmt.Errorf("unable to rename branch: branch '%s' not found", name)
+	}
+	if _, err := repo.Reference(newRef, false); err == nil {
+		return "", fmt.Errorf("unable to rename branch: a branch named '%s' already exists", newName)
+	}
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(newRef, ref.Hash())); err != nil {
+		return "", fmt.Errorf("unable to rename branch: %w", err)
+	}
+	if head, err := repo.Reference(plumbing.HEAD, false); err == nil && head.Target() == oldRef {
+		err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, newRef))
+		if err != nil {
+			return "", fmt.Errorf("unable to rename branch: %w", err)
+		}
+	}
+	if err := repo.Storer.RemoveReference(oldRef); err != nil {
+		return "", fmt.Errorf("unable to rename branch: %w", err)
+	}
+
+	// Move the branch config, e.g. its upstream.
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("unable to rename branch config: %w", err)
+	}
+	if branch, found := cfg.Branches[name]; found {
+		delete(cfg.Branches, name)
+		branch.Name = newName
+		cfg.Branches[newName] = branch
+		if err := repo.SetConfig(cfg); err != nil {
+			return "", fmt.Errorf("unable to rename branch config: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// Mirror creates a bare mirror clone of a remote, including all its refs.
+func (g *GoGit) Mirror(ctx context.Context, remote, path string) (string, error) {
+	if err := prepareClonePath(path); err != nil {
+		return "", err
+	}
+	_, err := git.PlainCloneContext(ctx, path, &git.CloneOptions{
+		URL:    remote,
+		Mirror: true,
+		Bare:   true,
+	})
+	if err != nil {
+		_ = os.RemoveAll(path)
+		return "", fmt.Errorf("unable to mirror: %w", goGitError(ctx, err))
+	}
+	return fmt.Sprintf("Cloned into bare repository '%s'", filepath.Base(path)), nil
+}
+
+// UpdateMirror updates a mirror clone incrementally. Deleted refs are not
+// pruned, see Fetch.
+func (g *GoGit) UpdateMirror(ctx context.Context, path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to update mirror: %w", err)
+	}
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		Force:      true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", fmt.Errorf("unable to update mirror: %w", goGitError(ctx, err))
+	}
+	return "", nil
+}
+
+// Bundle is not supported by go-git.
+func (g *GoGit) Bundle(_ context.Context, _, _ string, _ []string) (string, error) {
+	return "", fmt.Errorf("unable to create bundle: %w", ErrNotSupported)
+}
+
+// SetRemoteURL changes the URL of a remote.
+func (g *GoGit) SetRemoteURL(_ context.Context, path, name, url string) error {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("unable to set remote url: %w", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return fmt.Errorf("unable to set remote url: %w", err)
+	}
+	remote, found := cfg.Remotes[name]
+	if !found {
+		return fmt.Errorf("unable to set remote url: %w", git.ErrRemoteNotFound)
+	}
+	remote.URLs = []string{url}
+	if err := repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("unable to set remote url: %w", err)
+	}
+	return nil
+}
+
+// UpdateSubmodules initializes and checks out submodules recursively, at
+// the commits recorded in the repository.
+func (g *GoGit) UpdateSubmodules(ctx context.Context, path string) (string, error) {
+	submodules, err := worktreeSubmodules(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to update submodules: %w", err)
+	}
+	err = submodules.UpdateContext(ctx, &git.SubmoduleUpdateOptions{
+		Init:              true,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to update submodules: %w", goGitError(ctx, err))
+	}
+	return fmt.Sprintf("Updated %d submodule(s)", len(submodules)), nil
+}
+
+// Submodules returns the state of top-level submodules, go-git does not
+// report nested submodules.
+func (g *GoGit) Submodules(ctx context.Context, path string) ([]Submodule, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	submodules, err := worktreeSubmodules(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get submodules: %w", err)
+	}
+	statuses, err := submodules.Status()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get submodules: %w", err)
+	}
+	result := make([]Submodule, 0, len(statuses))
+	for _, status := range statuses {
+		submodule := Submodule{
+			Path:   status.Path,
+			Commit: status.Expected.String(),
+			State:  SubmoduleInSync,
+		}
+		switch {
+		case status.Current.IsZero():
+			submodule.State = SubmoduleUninitialized
+		case !status.IsClean():
+			submodule.State = SubmoduleOutOfSync
+		}
+		result = append(result, submodule)
+	}
+	return result, nil
+}
+
+// AddWorktree is not supported, go-git worktrees can't check out branches.
+func (g *GoGit) AddWorktree(_ context.Context, _, _, _, _ string) (string, error) {
+	return "", fmt.Errorf("unable to add worktree: %w", ErrNotSupported)
+}
+
+// Worktrees returns all worktrees of a repository, the main one first.
+// Linked worktrees are read from their administrative files in the git
+// directory.
+func (g *GoGit) Worktrees(ctx context.Context, path string) ([]Worktree, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list worktrees: %w", err)
+	}
+	main := Worktree{Path: path, Main: true}
+	if head, err := repo.Head(); err == nil {
+		main.Commit = head.Hash().String()
+		if head.Name().IsBranch() {
+			main.Branch = head.Name().Short()
+		} else {
+			main.Detached = true
+		}
+	}
+	worktrees := []Worktree{main}
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return worktrees, nil
+	}
+	adminDir := filepath.Join(storage.Filesystem().Root(), "worktrees")
+	entries, err := os.ReadDir(adminDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return worktrees, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to list worktrees: %w", err)
+	}
+	for _, entry := range entries {
+		dir := filepath.Join(adminDir, entry.Name())
+		gitdir, err := os.ReadFile(filepath.Join(dir, "gitdir"))
+		if err != nil {
+			continue
+		}
+		worktree := Worktree{Path: filepath.Dir(strings.TrimSpace(string(gitdir)))}
+		if head, err := os.ReadFile(filepath.Join(dir, "HEAD")); err == nil {
+			ref, isRef := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: ")
+			worktree.Commit = ref
+			if isRef {
+				worktree.Branch = plumbing.ReferenceName(ref).Short()
+				worktree.Commit = ""
+				if resolved, err := repo.Reference(plumbing.ReferenceName(ref), true); err == nil {
+					worktree.Commit = resolved.Hash().String()
+				}
+			}
+			worktree.Detached = !isRef
+		}
+		if _, err := os.Stat(filepath.Join(dir, "locked")); err == nil {
+			worktree.Locked = true
+		}
+		if _, err := os.Stat(worktree.Path); err != nil {
+			worktree.Prunable = true
+		}
+		worktrees = append(worktrees, worktree)
+	}
+	return worktrees, nil
+}
+
+// RemoveWorktree is not supported by go-git.
+func (g *GoGit) RemoveWorktree(_ context.Context, _, _ string) (string, error) {
+	return "", fmt.Errorf("unable to remove worktree: %w", ErrNotSupported)
+}
+
+// RepairWorktrees repairs the links between a moved repository and its
+// worktrees, like 'git worktree repair'.
+func (g *GoGit) RepairWorktrees(ctx context.Context, path string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("unable to repair worktrees: %w", err)
+	}
+
+	// A linked worktree, point its administrative directory back at it.
+	if !info.IsDir() {
+		content, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", fmt.Errorf("unable to repair worktrees: %w", err)
+		}
+		adminDir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+		if !found {
+			return "", fmt.Errorf("unable to repair worktrees: invalid %s", dotGit)
+		}
+		if !filepath.IsAbs(adminDir) {
+			adminDir = filepath.Join(path, adminDir)
+		}
+		if err := os.WriteFile(filepath.Join(adminDir, "gitdir"), []byte(dotGit+"\n"), 0o644); err != nil {
+			return "", fmt.Errorf("unable to repair worktrees: %w", err)
+		}
+		return "", nil
+	}
+
+	// A main clone, point its linked worktrees at the moved git directory.
+	adminRoot := filepath.Join(dotGit, "worktrees")
+	entries, err := os.ReadDir(adminRoot)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to repair worktrees: %w", err)
+	}
+	for _, entry := range entries {
+		adminDir := filepath.Join(adminRoot, entry.Name())
+		gitdir, err := os.ReadFile(filepath.Join(adminDir, "gitdir"))
+		if err != nil {
+			continue
+		}
+		worktreeGit := strings.TrimSpace(string(gitdir))
+		if _, err := os.Stat(worktreeGit); err != nil {
+			// Prunable worktree, its directory is gone.
+			continue
+		}
+		if err := os.WriteFile(worktreeGit, []byte("gitdir: "+adminDir+"\n"), 0o644); err != nil {
+			return "", fmt.Errorf("unable to repair worktrees: %w", err)
+		}
+	}
+	return "", nil
+}
+
+func worktreeSubmodules(path string) (git.Submodules, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	return w.Submodules()
+}
+
+func (g *GoGit) Log(ctx context.Context, path, ref string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, from, err := resolve(path, ref)
+	if err != nil {
+		return "", fmt.Errorf("error during log: %w", err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return "", fmt.Errorf("error during log: %w", err)
+	}
+	defer iter.Close()
+
+	lines := []string{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		if len(lines) >= logLimit {
+			return storer.ErrStop
+		}
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		lines = append(lines, fmt.Sprintf("* %s - %s (%s %s)",
+			c.Hash.String()[:7], subject, c.Author.Name, timeAgo(c.Author.When)))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error during log: %w", err)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (g *GoGit) CommitDates(ctx context.Context, path, branch string, days int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, from, err := resolve(path, branch)
+	if err != nil {
+		return nil, fmt.Errorf("error during commit dates: %w", err)
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	iter, err := repo.Log(&git.LogOptions{From: from, Since: &since})
+	if err != nil {
+		return nil, fmt.Errorf("error during commit dates: %w", err)
+	}
+	defer iter.Close()
+
+	dates := []string{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		dates = append(dates, c.Author.When.Format(time.DateOnly))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during commit dates: %w", err)
+	}
+	return dates, nil
+}
+
+// Commits returns commits of HEAD, or of all local branches, newest first.
+func (g *GoGit) Commits(ctx context.Context, path string, opts LogOptions) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var author *regexp.Regexp
+	if opts.Author != "" {
+		var err error
+		if author, err = regexp.Compile(opts.Author); err != nil {
+			return nil, fmt.Errorf("error during log: %w", err)
+		}
+	}
+	repo, head, err := resolve(path, "")
+	if err != nil {
+		return nil, fmt.Errorf("error during log: %w", err)
+	}
+	heads := []plumbing.Hash{head}
+	if opts.Branches {
+		heads = nil
+		branches, err := repo.Branches()
+		if err != nil {
+			return nil, fmt.Errorf("error during log: %w", err)
+		}
+		_ = branches.ForEach(func(ref *plumbing.Reference) error {
+			heads = append(heads, ref.Hash())
+			return nil
+		})
+	}
+
+	var since *time.Time
+	if !opts.Since.IsZero() {
+		since = &opts.Since
+	}
+	seen := map[plumbing.Hash]bool{}
+	commits := []Commit{}
+	for _, from := range heads {
+		iter, err := repo.Log(&git.LogOptions{From: from, Since: since})
+		if err != nil {
+			return nil, fmt.Errorf("error during log: %w", err)
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			if seen[c.Hash] {
+				return nil
+			}
+			seen[c.Hash] = true
+			signature := fmt.Sprintf("%s <%s>", c.Author.Name, c.Author.Email)
+			if author != nil && !author.MatchString(signature) {
+				return nil
+			}
+			subject, _, _ := strings.Cut(c.Message, "\n")
+			commits = append(commits, Commit{
+				Hash:    c.Hash.String(),
+				Author:  c.Author.Name,
+				Email:   c.Author.Email,
+				Date:    c.Author.When,
+				Subject: subject,
+			})
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error during log: %w", err)
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.After(commits[j].Date)
+	})
+	return commits, nil
+}
+
+// UserEmail returns the configured user email of a repository.
+func (g *GoGit) UserEmail(_ context.Context, path string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to get user email: %w", err)
+	}
+	cfg, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return "", fmt.Errorf("unable to get user email: %w", err)
+	}
+	return cfg.User.Email, nil
+}
+
+// Refs returns branch and tag names, sorted by most recent commit.
+func (g *GoGit) Refs(ctx context.Context, path string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("error during refs: %w", err)
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("error during refs: %w", err)
+	}
+
+	type datedRef struct {
+		name string
+		when time.Time
+	}
+	dated := []datedRef{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsBranch() && !ref.Name().IsTag() {
+			return nil
+		}
+		if commit, err := peelCommit(repo, ref.Hash()); err == nil {
+			dated = append(dated, datedRef{ref.Name().String(), commit.Committer.When})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during refs: %w", err)
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].when.After(dated[j].when)
+	})
+
+	names := make([]string, 0, len(dated))
+	for _, ref := range dated {
+		names = append(names, ref.name)
+	}
+	return names, nil
+}
+
+// Status returns the working tree status of a repository.
+func (g *GoGit) Status(ctx context.Context, path string) (Status, error) {
+	status := Status{}
+	if err := ctx.Err(); err != nil {
+		return status, err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	files, err := w.Status()
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	// go-git does not report unmerged paths, read their index stages. Merged
+	// entries are stored at stage 0, index.Merged is wrongly defined as 1.
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	unmerged := map[string]bool{}
+	for _, entry := range idx.Entries {
+		if entry.Stage != 0 {
+			unmerged[entry.Name] = true
+		}
+	}
+	status.Conflicted = len(unmerged)
+
+	for name, file := range files {
+		switch {
+		case unmerged[name]:
+			continue
+		case file.Worktree == git.Untracked:
+			status.Untracked++
+		case file.Staging == git.UpdatedButUnmerged || file.Worktree == git.UpdatedButUnmerged:
+			status.Conflicted++
+		default:
+			if file.Staging != git.Unmodified {
+				status.Staged++
+			}
+			if file.Worktree != git.Unmodified {
+				status.Unstaged++
+			}
+		}
+	}
+
+	status.Stashes, err = stashCount(path)
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	status.Operation, err = InProgress(path)
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+
+	head, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// Repository without commits yet.
+		return status, nil
+	}
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	status.Commit = head.Hash().String()
+	if !head.Name().IsBranch() {
+		status.Detached = true
+		return status, nil
+	}
+	status.Branch = head.Name().Short()
+
+	branch, err := repo.Branch(status.Branch)
+	if err != nil || branch.Remote == "" || branch.Merge == "" {
+		return status, nil
+	}
+	status.Upstream = branch.Remote + "/" + branch.Merge.Short()
+	upstream, err := repo.Reference(
+		plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short()), true)
+	if err != nil {
+		status.Gone = true
+		return status, nil
+	}
+	status.Ahead, status.Behind, err = countDiverged(repo, head.Hash(), upstream.Hash())
+	if err != nil {
+		return status, fmt.Errorf("unable to get status: %w", err)
+	}
+	return status, nil
+}
+
+// stashCount counts stash entries from the stash reflog, as go-git has no
+// reflog support.
+func stashCount(path string) (int, error) {
+	dir, err := commonDir(path)
+	if err != nil {
+		return 0, err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "logs", "refs", "stash"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strings.Count(string(content), "\n"), nil
+}
+
+// Describe returns the nearest tag and distance, or an abbreviated hash.
+func (g *GoGit) Describe(ctx context.Context, path string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, head, err := resolve(path, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to describe rev: %w", err)
+	}
+
+	tags := map[plumbing.Hash]string{}
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return "", fmt.Errorf("unable to describe rev: %w", err)
+	}
+	_ = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		if commit, err := peelCommit(repo, ref.Hash()); err == nil {
+			tags[commit.Hash] = ref.Name().Short()
+		}
+		return nil
+	})
+
+	iter, err := repo.Log(&git.LogOptions{From: head, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return "", fmt.Errorf("unable to describe rev: %w", err)
+	}
+	defer iter.Close()
+
+	distance := 0
+	description := head.String()[:7]
+	_ = iter.ForEach(func(c *object.Commit) error {
+		tag, found := tags[c.Hash]
+		if !found {
+			distance++
+			return nil
+		}
+		description = tag
+		if distance > 0 {
+			description = fmt.Sprintf("%s-%d-g%s", tag, distance, head.String()[:7])
+		}
+		return storer.ErrStop
+	})
+	return description, nil
+}
+
+// CurrentPosition returns a short description of HEAD and its refs.
+func (g *GoGit) CurrentPosition(ctx context.Context, path string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to get current rev: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("unable to get current rev: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("unable to get current rev: %w", err)
+	}
+
+	decorations := []string{"HEAD"}
+	if head.Name().IsBranch() {
+		decorations[0] = "HEAD -> " + head.Name().Short()
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return "", fmt.Errorf("unable to get current rev: %w", err)
+	}
+	_ = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name()
+		switch {
+		case ref.Type() != plumbing.HashReference, name == head.Name():
+		case name.IsRemote() && ref.Hash() == head.Hash():
+			decorations = append(decorations, name.Short())
+		case name.IsTag():
+			if c, err := peelCommit(repo, ref.Hash()); err == nil && c.Hash == head.Hash() {
+				decorations = append(decorations, "tag: "+name.Short())
+			}
+		}
+		return nil
+	})
+	return fmt.Sprintf("%s (%s %s)",
+		strings.Join(decorations, ", "),
+		commit.Author.Name,
+		timeAgo(commit.Author.When),
+	), nil
+}
+
+// Diff returns ahead/behind counts of branch compared to target.
+func (g *GoGit) Diff(ctx context.Context, path, branch, target string) (int, int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+	repo, branchHash, err := resolve(path, branch)
+	if err != nil {
+		return 0, 0, nil
+	}
+	targetHash, err := repo.ResolveRevision(plumbing.Revision(target))
+	if err != nil {
+		return 0, 0, nil
+	}
+	return countDiverged(repo, branchHash, *targetHash)
+}
+
+// openRepo opens a repository whose references may be removed. go-git
+// rewrites packed-refs through a temporary file in the system temp dir,
+// which its bound filesystem then refuses to rename.
+func openRepo(path string) (*git.Repository, error) {
+	dot := filepath.Join(path, git.GitDirName)
+	if info, err := os.Stat(dot); err != nil || !info.IsDir() {
+		// Linked worktrees and submodules.
+		return git.PlainOpen(path)
+	}
+	fs := localTempFS{osfs.New(dot, osfs.WithBoundOS())}
+	storage := filesystem.NewStorage(fs, cache.NewObjectLRUDefault())
+	return git.Open(storage, osfs.New(path, osfs.WithBoundOS()))
+}
+
+// localTempFS creates temporary files in the filesystem root by default.
+type localTempFS struct {
+	billy.Filesystem
+}
+
+func (fs localTempFS) TempFile(dir, prefix string) (billy.File, error) {
+	if dir == "" {
+		dir = "."
+	}
+	return fs.Filesystem.TempFile(dir, prefix)
+}
+
+// resolve opens a repository and resolves a revision, or HEAD if empty.
+func resolve(path, rev string) (*git.Repository, plumbing.Hash, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	if rev == "" {
+		rev = "HEAD"
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("unable to resolve %q: %w", rev, err)
+	}
+	return repo, *hash, nil
+}
+
+// peelCommit returns the commit a hash points to, peeling annotated tags.
+func peelCommit(repo *git.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	if tag, err := repo.TagObject(hash); err == nil {
+		return tag.Commit()
+	}
+	return repo.CommitObject(hash)
+}
+
+// countDiverged returns the number of commits reachable only from a, and
+// only from b.
+func countDiverged(repo *git.Repository, a, b plumbing.Hash) (int, int, error) {
+	if a == b {
+		return 0, 0, nil
+	}
+	ancestorsA, err := ancestors(repo, a)
+	if err != nil {
+		return 0, 0, err
+	}
+	ancestorsB, err := ancestors(repo, b)
+	if err != nil {
+		return 0, 0, err
+	}
+	onlyA, onlyB := 0, 0
+	for hash := range ancestorsA {
+		if _, found := ancestorsB[hash]; !found {
+			onlyA++
+		}
+	}
+	for hash := range ancestorsB {
+		if _, found := ancestorsA[hash]; !found {
+			onlyB++
+		}
+	}
+	return onlyA, onlyB, nil
+}
+
+// ancestors returns the set of commits reachable from a commit.
+func ancestors(repo *git.Repository, from plumbing.Hash) (map[plumbing.Hash]struct{}, error) {
+	set := map[plumbing.Hash]struct{}{}
+	iter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	err = iter.ForEach(func(c *object.Commit) error {
+		set[c.Hash] = struct{}{}
+		return nil
+	})
+	return set, err
+}
+
+// goGitError converts go-git errors into known causes.
+func goGitError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var cause error
+	switch {
+	case errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed):
+		cause = ErrAuthFailed
+	case errors.Is(err, transport.ErrRepositoryNotFound):
+		cause = ErrRepoNotFound
+	case errors.Is(err, git.ErrNonFastForwardUpdate):
+		cause = ErrNonFastForward
+	case errors.Is(err, git.ErrUnstagedChanges),
+		errors.Is(err, git.ErrWorktreeNotClean):
+		cause = ErrLocalChanges
+	default:
+		cause = classify(err.Error())
+	}
+	return &Error{Cause: cause, Err: err}
+}
+
+// timeAgo returns a human-readable relative time, similar to git's.
+func timeAgo(t time.Time) string {
+	elapsed := time.Since(t)
+	units := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"month", 30 * 24 * time.Hour},
+		{"week", 7 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	for _, unit := range units {
+		if count := int(elapsed / unit.duration); count > 0 {
+			if count == 1 {
+				return fmt.Sprintf("1 %s ago", unit.name)
+			}
+			return fmt.Sprintf("%d %ss ago", count, unit.name)
+		}
+	}
+	return fmt.Sprintf("%d seconds ago", int(elapsed.Seconds()))
+}
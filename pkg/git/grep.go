@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GrepOptions control a repository search.
+type GrepOptions struct {
+	// Pattern is an extended regular expression.
+	Pattern string
+	// Ref searches a branch, tag or commit instead of the working tree.
+	Ref string
+	// FilesOnly lists matching file names, without lines.
+	FilesOnly bool
+}
+
+// GrepMatch is a single matching line, or file if listing files only.
+type GrepMatch struct {
+	File string
+	Line int
+	Text string
+}
+
+// Grep searches tracked files of the working tree, or of a ref.
+func (g *Shell) Grep(ctx context.Context, path string, opts GrepOptions) ([]GrepMatch, error) {
+	args := []string{"grep", "-I", "-E", "-z", "--no-color"}
+	if opts.FilesOnly {
+		args = append(args, "-l")
+	} else {
+		args = append(args, "-n")
+	}
+	args = append(args, "-e", opts.Pattern)
+	if opts.Ref != "" {
+		args = append(args, opts.Ref, "--")
+	}
+
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		// Exit status 1 without errors means nothing matched.
+		var exitErr *exec.ExitError
+		var gitErr *Error
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 &&
+			errors.As(err, &gitErr) && gitErr.Stderr == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to grep: %w", err)
+	}
+	return parseGrep(string(output), opts), nil
+}
+
+// parseGrep parses 'git grep -z' output, with line numbers unless listing
+// files only. File names are prefixed by the ref when searching one.
+func parseGrep(output string, opts GrepOptions) []GrepMatch {
+	matches := []GrepMatch{}
+	prefix := ""
+	if opts.Ref != "" {
+		prefix = opts.Ref + ":"
+	}
+	if opts.FilesOnly {
+		for _, file := range strings.Split(output, "\x00") {
+			file = strings.TrimSpace(file)
+			if file != "" {
+				matches = append(matches, GrepMatch{File: strings.TrimPrefix(file, prefix)})
+			}
+		}
+		return matches
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		lineNum, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		matches = append(matches, GrepMatch{
+			File: strings.TrimPrefix(fields[0], prefix),
+			Line: lineNum,
+			Text: fields[2],
+		})
+	}
+	return matches
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGrep(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		opts   GrepOptions
+		want   []GrepMatch
+	}{
+		{
+			name:   "empty",
+			output: "",
+			want:   []GrepMatch{},
+		},
+		{
+			name:   "lines",
+			output: "main.go\x0012\x00// TODO: fix\npkg/a b.go\x003\x00x := 1\x00 // NUL in text\n",
+			want: []GrepMatch{
+				{File: "main.go", Line: 12, Text: "// TODO: fix"},
+				{File: "pkg/a b.go", Line: 3, Text: "x := 1\x00 // NUL in text"},
+			},
+		},
+		{
+			name:   "lines of ref",
+			output: "main:main.go\x007\x00func main() {\n",
+			opts:   GrepOptions{Ref: "main"},
+			want:   []GrepMatch{{File: "main.go", Line: 7, Text: "func main() {"}},
+		},
+		{
+			name:   "skips malformed lines",
+			output: "main.go\x00x\x00text\nno separators\n",
+			want:   []GrepMatch{},
+		},
+		{
+			name:   "files only",
+			output: "main.go\x00pkg/a b.go\x00",
+			opts:   GrepOptions{FilesOnly: true},
+			want:   []GrepMatch{{File: "main.go"}, {File: "pkg/a b.go"}},
+		},
+		{
+			name:   "files only of ref",
+			output: "v1.0:main.go\x00",
+			opts:   GrepOptions{Ref: "v1.0", FilesOnly: true},
+			want:   []GrepMatch{{File: "main.go"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseGrep(tt.output, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGrep() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// Mirror creates a bare mirror clone of a remote, including all its refs.
+func (g *Shell) Mirror(ctx context.Context, remote, path string) (string, error) {
+	if err := prepareClonePath(path); err != nil {
+		return "", err
+	}
+	args := []string{"clone", "--mirror", remote, path}
+	output, err := g.Exec(ctx, filepath.Dir(path), args)
+	if err != nil {
+		return "", fmt.Errorf("unable to mirror: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// UpdateMirror updates a mirror clone incrementally, pruning deleted refs.
+func (g *Shell) UpdateMirror(ctx context.Context, path string) (string, error) {
+	args := []string{"remote", "update", "--prune"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to update mirror: %w", err)
+	}
+	return cleanOutput(output), nil
+}
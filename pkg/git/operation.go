@@ -0,0 +1,97 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Operation is a multi-step git operation in progress.
+type Operation string
+
+const (
+	OperationNone       Operation = ""
+	OperationRebase     Operation = "rebase"
+	OperationMerge      Operation = "merge"
+	OperationCherryPick Operation = "cherry-pick"
+	OperationRevert     Operation = "revert"
+	OperationBisect     Operation = "bisect"
+	OperationApplyMail  Operation = "am"
+)
+
+// operationMarkers are files in the git directory, present while an
+// operation is in progress, in order of precedence.
+var operationMarkers = []struct {
+	name      string
+	operation Operation
+}{
+	{"rebase-merge", OperationRebase},
+	{"rebase-apply/applying", OperationApplyMail},
+	{"rebase-apply", OperationRebase},
+	{"MERGE_HEAD", OperationMerge},
+	{"CHERRY_PICK_HEAD", OperationCherryPick},
+	{"REVERT_HEAD", OperationRevert},
+	{"BISECT_LOG", OperationBisect},
+}
+
+// InProgress returns the operation in progress in a repository, if any.
+func InProgress(path string) (Operation, error) {
+	dir, err := gitDir(path)
+	if err != nil {
+		return OperationNone, err
+	}
+	for _, marker := range operationMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker.name)); err == nil {
+			return marker.operation, nil
+		}
+	}
+	return OperationNone, nil
+}
+
+// gitDir returns the git directory of a working tree, following the
+// 'gitdir:' file of linked worktrees and submodules.
+func gitDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("unable to find git directory: %w", err)
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("unable to read git directory: %w", err)
+	}
+	dir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !found {
+		return "", fmt.Errorf("unable to parse %s", dotGit)
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(path, dir)
+	}
+	return dir, nil
+}
+
+// commonDir returns the git directory shared by all worktrees of a
+// repository, following the 'commondir' file of linked worktrees.
+func commonDir(path string) (string, error) {
+	dir, err := gitDir(path)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "commondir"))
+	if os.IsNotExist(err) {
+		return dir, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to read common directory: %w", err)
+	}
+	common := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(dir, common)
+	}
+	return filepath.Clean(common), nil
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PullStrategy is how pulled changes are integrated into the current branch.
+type PullStrategy string
+
+const (
+	PullFastForward PullStrategy = "ff-only"
+	PullRebase      PullStrategy = "rebase"
+	PullMerge       PullStrategy = "merge"
+)
+
+// PullOptions control how a pull integrates remote changes.
+type PullOptions struct {
+	// Strategy defaults to fast-forward only.
+	Strategy PullStrategy
+	// Autostash stashes local changes before pulling, and restores them after.
+	Autostash bool
+}
+
+// PullResult is the outcome of a successful pull.
+type PullResult struct {
+	Output   string
+	Strategy PullStrategy
+	// Stashed is true if local changes were stashed.
+	Stashed bool
+	// StashConflict is true if stashed changes conflicted when restored, and
+	// remain in the stash.
+	StashConflict bool
+}
+
+// Pull fetches from remote and integrates with the current branch. Rebase or
+// merge conflicts are aborted, leaving the repository as it was. Repositories
+// with an operation already in progress are not pulled, so it is never
+// aborted.
+func (g *Shell) Pull(ctx context.Context, path string, opts PullOptions) (PullResult, error) {
+	result := PullResult{Strategy: opts.Strategy}
+	if result.Strategy == "" {
+		result.Strategy = PullFastForward
+	}
+	operation, err := InProgress(path)
+	if err != nil {
+		return result, fmt.Errorf("error during pull: %w", err)
+	}
+	if operation != OperationNone {
+		return result, fmt.Errorf("error during pull: %s in progress", operation)
+	}
+
+	args := []string{"pull", "--stat", "--no-verbose"}
+	switch result.Strategy {
+	case PullFastForward:
+		args = append(args, "--ff-only")
+	case PullRebase:
+		args = append(args, "--rebase")
+	case PullMerge:
+		args = append(args, "--no-rebase")
+	default:
+		return result, fmt.Errorf("unknown pull strategy %q", result.Strategy)
+	}
+	if opts.Autostash {
+		args = append(args, "--autostash")
+	} else {
+		args = append(args, "--no-autostash")
+	}
+
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		if aborted := g.abortOperation(ctx, path); aborted != OperationNone {
+			return result, fmt.Errorf("error during pull, %s aborted: %w", aborted, err)
+		}
+		return result, fmt.Errorf("error during pull: %w", err)
+	}
+	result.Output = cleanOutput(output)
+	result.Stashed = strings.Contains(result.Output, "Created autostash")
+	result.StashConflict = strings.Contains(result.Output, "Applying autostash resulted in conflicts")
+	return result, nil
+}
+
+// abortOperation aborts a rebase or merge left in progress by a failed pull,
+// and returns the aborted operation. It must only be called when no operation
+// was in progress before the pull.
+func (g *Shell) abortOperation(ctx context.Context, path string) Operation {
+	operation, err := InProgress(path)
+	if err != nil {
+		return OperationNone
+	}
+	var args []string
+	switch operation {
+	case OperationRebase:
+		args = []string{"rebase", "--abort"}
+	case OperationMerge:
+		args = []string{"merge", "--abort"}
+	default:
+		return OperationNone
+	}
+	// Aborting must not be cancelled along with the failed pull.
+	if _, err := g.Exec(context.WithoutCancel(ctx), path, args); err != nil {
+		log.Warnf("unable to abort %s at %s: %s", operation, path, err)
+		return OperationNone
+	}
+	return operation
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PushOptions control which branch is pushed.
+type PushOptions struct {
+	// Remote and Branch push a local branch to a remote branch of the same
+	// name. Otherwise the current branch is pushed to its upstream.
+	Remote string
+	Branch string
+	// SetUpstream sets the pushed remote branch as upstream of Branch.
+	SetUpstream bool
+}
+
+// Push pushes the current branch to its upstream, or Branch to a remote
+// branch of the same name. The remote and refspec are always explicit, so
+// the user's push.default doesn't apply.
+func (g *Shell) Push(ctx context.Context, path string, opts PushOptions) (string, error) {
+	local, remote, merge := "refs/heads/"+opts.Branch, opts.Remote, "refs/heads/"+opts.Branch
+	if opts.Branch == "" {
+		var err error
+		if local, remote, merge, err = g.upstream(ctx, path); err != nil {
+			return "", fmt.Errorf("error during push: %w", err)
+		}
+	}
+	args := []string{"push"}
+	if opts.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote, local+":"+merge)
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("error during push: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// upstream returns the current branch reference, and its configured upstream
+// remote and merge reference.
+func (g *Shell) upstream(ctx context.Context, path string) (string, string, string, error) {
+	output, err := g.Exec(ctx, path, []string{"symbolic-ref", "--quiet", "HEAD"})
+	if err != nil {
+		return "", "", "", ErrNoUpstream
+	}
+	local := cleanOutput(output)
+	branch := strings.TrimPrefix(local, "refs/heads/")
+	output, err = g.Exec(ctx, path, []string{"config", "branch." + branch + ".remote"})
+	if err != nil {
+		return "", "", "", ErrNoUpstream
+	}
+	remote := cleanOutput(output)
+	output, err = g.Exec(ctx, path, []string{"config", "branch." + branch + ".merge"})
+	if err != nil {
+		return "", "", "", ErrNoUpstream
+	}
+	return local, remote, cleanOutput(output), nil
+}
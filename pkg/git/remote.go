@@ -0,0 +1,57 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AddRemote adds a remote to a repository.
+func (g *Shell) AddRemote(ctx context.Context, path, name, url string) error {
+	args := []string{"remote", "add", name, url}
+	if _, err := g.Exec(ctx, path, args); err != nil {
+		return fmt.Errorf("unable to add remote: %w", err)
+	}
+	return nil
+}
+
+// DefaultBranch returns the default branch of a remote, from its local HEAD
+// reference if set, otherwise by querying the remote.
+func (g *Shell) DefaultBranch(ctx context.Context, path, remote string) (string, error) {
+	args := []string{"rev-parse", "--abbrev-ref", remote + "/HEAD"}
+	if output, err := g.Exec(ctx, path, args); err == nil {
+		return strings.TrimPrefix(cleanOutput(output), remote+"/"), nil
+	}
+
+	args = []string{"ls-remote", "--symref", remote, "HEAD"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to find default branch: %w", err)
+	}
+	for _, line := range strings.Split(cleanOutput(output), "\n") {
+		ref, found := strings.CutPrefix(line, "ref: ")
+		if found {
+			ref, _, _ = strings.Cut(ref, "\t")
+			return strings.TrimPrefix(ref, "refs/heads/"), nil
+		}
+	}
+	return "", fmt.Errorf("unable to find default branch of %s", remote)
+}
+
+// FastForward fast-forwards a local branch to target, updating the working
+// tree if the branch is checked out.
+func (g *Shell) FastForward(ctx context.Context, path, branch, target string) (string, error) {
+	current, err := g.CurrentBranch(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	args := []string{"fetch", "--no-verbose", ".", target + ":refs/heads/" + branch}
+	if current == branch {
+		args = []string{"merge", "--ff-only", "--stat", target}
+	}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to fast-forward: %w", err)
+	}
+	return cleanOutput(output), nil
+}
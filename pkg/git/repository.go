@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,15 +12,122 @@ import (
 
 var ErrNoUpstream = errors.New("no Upstream Tracking branch found")
 
-type Repository struct {
+// Repository is an opened git repository, for queries and operations on its
+// branches and remotes.
+type Repository interface {
+	// Branches returns the short names of all branch and tag references.
+	Branches(ctx context.Context) ([]string, error)
+	CurrentBranch(ctx context.Context) (string, error)
+	IsLocalBranch(ctx context.Context, branch string) bool
+	IsRemoteBranch(ctx context.Context, remote, branch string) bool
+	Remotes(ctx context.Context) ([]string, error)
+	// Checkout switches to a local branch, creating it from branch if
+	// missing, i.e. when branch is a remote branch or a tag.
+	Checkout(ctx context.Context, branch string) error
+	// Upstream returns the short name of the upstream branch, or
+	// ErrNoUpstream.
+	Upstream(ctx context.Context, branch string) (string, error)
+}
+
+// shellRepository is a repository client that runs the git executable.
+type shellRepository struct {
+	shell *Shell
+	path  string
+}
+
+func (r *shellRepository) Branches(ctx context.Context) ([]string, error) {
+	args := []string{"for-each-ref", "--format=%(refname:short)%1f%(symref)"}
+	output, err := r.shell.Exec(ctx, r.path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list branches: %w", err)
+	}
+	branches := []string{}
+	for _, line := range strings.Split(cleanOutput(output), "\n") {
+		name, symref, _ := strings.Cut(line, "\x1f")
+		if name != "" && symref == "" {
+			branches = append(branches, name)
+		}
+	}
+	return branches, nil
+}
+
+func (r *shellRepository) CurrentBranch(ctx context.Context) (string, error) {
+	return r.shell.CurrentBranch(ctx, r.path)
+}
+
+func (r *shellRepository) IsLocalBranch(ctx context.Context, branch string) bool {
+	return r.hasRef(ctx, "refs/heads/"+branch)
+}
+
+func (r *shellRepository) IsRemoteBranch(ctx context.Context, remote, branch string) bool {
+	return r.hasRef(ctx, "refs/remotes/"+remote+"/"+branch)
+}
+
+func (r *shellRepository) hasRef(ctx context.Context, ref string) bool {
+	args := []string{"rev-parse", "--verify", "--quiet", ref}
+	_, err := r.shell.Exec(ctx, r.path, args)
+	return err == nil
+}
+
+func (r *shellRepository) Remotes(ctx context.Context) ([]string, error) {
+	output, err := r.shell.Exec(ctx, r.path, []string{"remote"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list remotes: %w", err)
+	}
+	remotes := []string{}
+	for _, remote := range strings.Split(cleanOutput(output), "\n") {
+		if remote != "" {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes, nil
+}
+
+func (r *shellRepository) Checkout(ctx context.Context, branch string) error {
+	remotes, err := r.Remotes(ctx)
+	if err != nil {
+		return err
+	}
+	name := stripRemote(branch, remotes)
+
+	args := []string{"switch", name}
+	if !r.IsLocalBranch(ctx, name) {
+		args = []string{"switch", "--create", name, branch}
+	}
+	if _, err := r.shell.Exec(ctx, r.path, args); err != nil {
+		return fmt.Errorf("unable to checkout %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (r *shellRepository) Upstream(ctx context.Context, branch string) (string, error) {
+	args := []string{"for-each-ref", "--format=%(upstream:short)", "refs/heads/" + branch}
+	output, err := r.shell.Exec(ctx, r.path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to get branch: %w", err)
+	}
+	upstream := cleanOutput(output)
+	if upstream == "" {
+		return "", ErrNoUpstream
+	}
+	return upstream, nil
+}
+
+// goGitRepository is a pure go-git repository client.
+type goGitRepository struct {
 	client *git.Repository
 }
 
-func (r *Repository) Client() *git.Repository {
-	return r.client
+// openRepository opens a go-git repository client.
+func openRepository(path string) (*goGitRepository, error) {
+	client, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	return &goGitRepository{client: client}, nil
 }
 
-func (r *Repository) Branches() ([]string, error) {
+func (r *goGitRepository) Branches(_ context.Context) ([]string, error) {
 	branches := []string{}
 	refs, err := r.client.References()
 	if err != nil {
@@ -34,7 +142,7 @@ func (r *Repository) Branches() ([]string, error) {
 	return branches, err
 }
 
-func (r *Repository) CurrentBranch() (string, error) {
+func (r *goGitRepository) CurrentBranch(_ context.Context) (string, error) {
 	head, err := r.client.Head()
 	if err != nil {
 		return "", err
@@ -42,19 +150,19 @@ func (r *Repository) CurrentBranch() (string, error) {
 	return head.Name().Short(), nil
 }
 
-func (r *Repository) IsLocalBranch(branch string) bool {
+func (r *goGitRepository) IsLocalBranch(_ context.Context, branch string) bool {
 	_, err := r.client.Reference(
 		plumbing.NewBranchReferenceName(branch), true)
 	return err == nil
 }
 
-func (r *Repository) IsRemoteBranch(remote, branch string) bool {
+func (r *goGitRepository) IsRemoteBranch(_ context.Context, remote, branch string) bool {
 	_, err := r.client.Reference(
 		plumbing.NewRemoteReferenceName(remote, branch), true)
 	return err == nil
 }
 
-func (r *Repository) Remotes() ([]string, error) {
+func (r *goGitRepository) Remotes(_ context.Context) ([]string, error) {
 	s := []string{}
 	remotes, err := r.client.Remotes()
 	if err != nil {
@@ -66,12 +174,12 @@ func (r *Repository) Remotes() ([]string, error) {
 	return s, nil
 }
 
-func (r *Repository) Checkout(branch string) error {
-	branchNoRemote, err := r.stripRemoteFromBranch(branch)
+func (r *goGitRepository) Checkout(ctx context.Context, branch string) error {
+	remotes, err := r.Remotes(ctx)
 	if err != nil {
 		return err
 	}
-	branchRef := plumbing.NewBranchReferenceName(branchNoRemote)
+	branchRef := plumbing.NewBranchReferenceName(stripRemote(branch, remotes))
 
 	opts := &git.CheckoutOptions{
 		Create: false,
@@ -94,7 +202,7 @@ func (r *Repository) Checkout(branch string) error {
 	return w.Checkout(opts)
 }
 
-func (r *Repository) GetUpstream(branch string) (upstream plumbing.ReferenceName, err error) {
+func (r *goGitRepository) Upstream(_ context.Context, branch string) (string, error) {
 	local, err := r.client.Branch(branch)
 	if err != nil {
 		return "", fmt.Errorf("unable to get branch: %w", err)
@@ -110,24 +218,20 @@ func (r *Repository) GetUpstream(branch string) (upstream plumbing.ReferenceName
 
 	for _, f := range remote.Config().Fetch {
 		if f.Match(local.Merge) {
-			return f.Dst(local.Merge), nil
+			return f.Dst(local.Merge).Short(), nil
 		}
 	}
 
 	return "", ErrNoUpstream
 }
 
-func (r *Repository) stripRemoteFromBranch(branch string) (string, error) {
-	remotes, err := r.Remotes()
-	if err != nil {
-		return "", err
-	}
-
+// stripRemote removes a remote name prefix from branch.
+func stripRemote(branch string, remotes []string) string {
 	for _, remote := range remotes {
 		if remote == "" {
 			continue
 		}
 		branch = strings.TrimPrefix(branch, remote+"/")
 	}
-	return branch, nil
+	return branch
 }
@@ -1,61 +1,25 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-func (g *Git) CurrentBranch(path string) (string, error) {
+func (g *Shell) CurrentBranch(ctx context.Context, path string) (string, error) {
 	args := []string{"rev-parse", "--abbrev-ref", "HEAD"}
-	abbrRef, err := g.Exec(path, args)
+	abbrRef, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("unable to find ref: %w", err)
 	}
 	return cleanOutput(abbrRef), nil
 }
 
-func (g *Git) UpstreamBranch(path string) (string, error) {
-	args := []string{"rev-parse", "--abbrev-ref", "@{upstream}"}
-	abbrRefUpstream, _ := g.Exec(path, args)
-	upstream := cleanOutput(abbrRefUpstream)
-	return upstream, nil
-}
-
-// GitModified returns the number of modified files
-func (g *Git) Modified(path string) (int, error) {
-	args := []string{"diff", "--shortstat"}
-	output, err := g.Exec(path, args)
-	if err != nil {
-		return 0, fmt.Errorf("unable to find modified diff: %w", err)
-	}
-	pat := regexp.MustCompile(`^\s*(\d+)`)
-	matches := pat.FindAllStringSubmatch(string(output), -1)
-	if len(matches) > 0 {
-		modified, err := strconv.Atoi(matches[0][1])
-		if err != nil {
-			return 0, fmt.Errorf("unable to convert string to int: %w", err)
-		}
-		return modified, nil
-	}
-	return 0, nil
-}
-
-// Untracked returns the number of untracked files
-func (g *Git) Untracked(path string) (int, error) {
-	args := []string{"ls-files", "--others", "--exclude-standard"}
-	output, err := g.Exec(path, args)
-	if err != nil {
-		return 0, fmt.Errorf("unable to find untracked: %w", err)
-	}
-	return len(strings.Split(string(output), "\n")) - 1, nil
-}
-
 // CurrentPosition returns a short log description of HEAD
-func (g *Git) CurrentPosition(path string) (string, error) {
+func (g *Shell) CurrentPosition(ctx context.Context, path string) (string, error) {
 	args := []string{"log", "-1", "--color=always", "--format=%C(auto)%D %C(242)(%aN %ar)%Creset"}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("unable to get current rev: %w", err)
 	}
@@ -63,9 +27,9 @@ func (g *Git) CurrentPosition(path string) (string, error) {
 }
 
 // Describe generates a version description based on tags and hash
-func (g *Git) Describe(path string) (string, error) {
+func (g *Shell) Describe(ctx context.Context, path string) (string, error) {
 	args := []string{"describe", "--tags", "--always"}
-	output, err := g.Exec(path, args)
+	output, err := g.Exec(ctx, path, args)
 	if err != nil {
 		return "", fmt.Errorf("unable to describe rev: %w", err)
 	}
@@ -73,9 +37,9 @@ func (g *Git) Describe(path string) (string, error) {
 }
 
 // Diff returns a formatted string of ahead/behind counts
-func (g *Git) Diff(path, branch, target string) (int, int, error) {
+func (g *Shell) Diff(ctx context.Context, path, branch, target string) (int, int, error) {
 	args := []string{"rev-list", "--left-right", branch + "..." + target}
-	output, _ := g.Exec(path, args)
+	output, _ := g.Exec(ctx, path, args)
 	outputStr := cleanOutput(output)
 
 	if len(outputStr) == 0 {
@@ -98,3 +62,115 @@ func (g *Git) Diff(path, branch, target string) (int, int, error) {
 	}
 	return ahead, behind, nil
 }
+
+// Status represents a working tree status, parsed from a single
+// 'git status --porcelain=v2' run.
+type Status struct {
+	Commit   string
+	Branch   string
+	Upstream string
+	// Gone is true if the upstream is set, but no longer exists.
+	Gone       bool
+	Detached   bool
+	Ahead      int
+	Behind     int
+	Staged     int
+	Unstaged   int
+	Untracked  int
+	Conflicted int
+	Stashes    int
+	Operation  Operation
+}
+
+// Status returns the parsed working tree status of a repository.
+func (g *Shell) Status(ctx context.Context, path string) (Status, error) {
+	args := []string{"status", "--porcelain=v2", "--branch", "--show-stash"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return Status{}, fmt.Errorf("unable to get status: %w", err)
+	}
+	status, err := parseStatus(string(output))
+	if err != nil {
+		return status, err
+	}
+	status.Operation, err = InProgress(path)
+	return status, err
+}
+
+// parseStatus parses 'git status --porcelain=v2 --branch --show-stash' output.
+// See https://git-scm.com/docs/git-status#_porcelain_format_version_2
+func parseStatus(output string) (Status, error) {
+	status := Status{}
+	hasAheadBehind := false
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch line[0] {
+		case '#':
+			if err := parseStatusHeader(fields, &status); err != nil {
+				return status, err
+			}
+			// Ahead/behind counts are missing when the upstream is gone.
+			hasAheadBehind = hasAheadBehind || (len(fields) > 1 && fields[1] == "branch.ab")
+
+		case '1', '2':
+			// Ordinary changed, renamed or copied entries.
+			if len(fields) < 2 || len(fields[1]) != 2 {
+				return status, fmt.Errorf("unable to parse status line %q", line)
+			}
+			if fields[1][0] != '.' {
+				status.Staged++
+			}
+			if fields[1][1] != '.' {
+				status.Unstaged++
+			}
+
+		case 'u':
+			status.Conflicted++
+
+		case '?':
+			status.Untracked++
+		}
+	}
+	status.Gone = status.Upstream != "" && !hasAheadBehind
+	return status, nil
+}
+
+// parseStatusHeader parses a single porcelain v2 header line.
+func parseStatusHeader(fields []string, status *Status) error {
+	if len(fields) < 3 {
+		return nil
+	}
+	var err error
+	switch fields[1] {
+	case "branch.oid":
+		if fields[2] != "(initial)" {
+			status.Commit = fields[2]
+		}
+	case "branch.head":
+		if fields[2] == "(detached)" {
+			status.Detached = true
+		} else {
+			status.Branch = fields[2]
+		}
+	case "branch.upstream":
+		status.Upstream = fields[2]
+	case "branch.ab":
+		if len(fields) < 4 {
+			return fmt.Errorf("unable to parse ahead/behind %q", fields)
+		}
+		if status.Ahead, err = strconv.Atoi(strings.TrimPrefix(fields[2], "+")); err != nil {
+			return fmt.Errorf("unable to parse ahead count: %w", err)
+		}
+		if status.Behind, err = strconv.Atoi(strings.TrimPrefix(fields[3], "-")); err != nil {
+			return fmt.Errorf("unable to parse behind count: %w", err)
+		}
+	case "stash":
+		if status.Stashes, err = strconv.Atoi(fields[2]); err != nil {
+			return fmt.Errorf("unable to parse stash count: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    Status
+		wantErr bool
+	}{
+		{
+			name:   "empty",
+			output: "",
+			want:   Status{},
+		},
+		{
+			name: "clean branch with upstream",
+			output: "# branch.oid 5b58545a1f\n" +
+				"# branch.head main\n" +
+				"# branch.upstream origin/main\n" +
+				"# branch.ab +2 -3\n",
+			want: Status{
+				Commit:   "5b58545a1f",
+				Branch:   "main",
+				Upstream: "origin/main",
+				Ahead:    2,
+				Behind:   3,
+			},
+		},
+		{
+			name: "gone upstream",
+			output: "# branch.oid 5b58545a1f\n" +
+				"# branch.head feature\n" +
+				"# branch.upstream origin/feature\n",
+			want: Status{
+				Commit:   "5b58545a1f",
+				Branch:   "feature",
+				Upstream: "origin/feature",
+				Gone:     true,
+			},
+		},
+		{
+			name: "initial commit",
+			output: "# branch.oid (initial)\n" +
+				"# branch.head main\n",
+			want: Status{Branch: "main"},
+		},
+		{
+			name: "detached with stashes",
+			output: "# branch.oid 5b58545a1f\n" +
+				"# branch.head (detached)\n" +
+				"# stash 3\n",
+			want: Status{Commit: "5b58545a1f", Detached: true, Stashes: 3},
+		},
+		{
+			name: "changes",
+			output: "# branch.head main\n" +
+				"1 M. N... 100644 100644 100644 aaa bbb staged.go\n" +
+				"1 .M N... 100644 100644 100644 aaa bbb unstaged.go\n" +
+				"1 MM N... 100644 100644 100644 aaa bbb both.go\n" +
+				"2 R. N... 100644 100644 100644 aaa bbb R100 new.go\told.go\n" +
+				"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go\n" +
+				"? untracked.go\n" +
+				"? other.go\n" +
+				"! ignored.go\n",
+			want: Status{
+				Branch:     "main",
+				Staged:     3,
+				Unstaged:   2,
+				Untracked:  2,
+				Conflicted: 1,
+			},
+		},
+		{
+			name:    "malformed change",
+			output:  "1 M\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed ahead behind",
+			output:  "# branch.ab +x -0\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed stash",
+			output:  "# stash many\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatus(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatusHeaderIgnoresShortLines(t *testing.T) {
+	status := Status{}
+	if err := parseStatusHeader([]string{"#", "branch.head"}, &status); err != nil {
+		t.Fatalf("parseStatusHeader() error = %v", err)
+	}
+	if !reflect.DeepEqual(status, Status{}) {
+		t.Errorf("parseStatusHeader() = %+v, want zero status", status)
+	}
+}
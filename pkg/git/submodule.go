@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SubmoduleState is the state of a submodule checkout.
+type SubmoduleState string
+
+const (
+	SubmoduleInSync        SubmoduleState = "in-sync"
+	SubmoduleUninitialized SubmoduleState = "uninitialized"
+	SubmoduleOutOfSync     SubmoduleState = "out-of-sync"
+	SubmoduleConflict      SubmoduleState = "conflict"
+)
+
+// Submodule is a submodule checkout compared to its recorded commit.
+type Submodule struct {
+	Path   string
+	Commit string
+	State  SubmoduleState
+}
+
+// InSync returns true if the submodule is checked out at its recorded commit.
+func (s Submodule) InSync() bool {
+	return s.State == SubmoduleInSync
+}
+
+// UpdateSubmodules initializes and checks out submodules recursively, at
+// the commits recorded in the repository.
+func (g *Shell) UpdateSubmodules(ctx context.Context, path string) (string, error) {
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to update submodules: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// Submodules returns the state of all submodules, recursively.
+func (g *Shell) Submodules(ctx context.Context, path string) ([]Submodule, error) {
+	args := []string{"submodule", "status", "--recursive"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get submodules: %w", err)
+	}
+	return parseSubmodules(string(output)), nil
+}
+
+// parseSubmodules parses the output of 'git submodule status'.
+func parseSubmodules(output string) []Submodule {
+	var submodules []Submodule
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) < 2 {
+			continue
+		}
+		submodule := Submodule{Commit: fields[0], Path: fields[1]}
+		switch line[0] {
+		case '-':
+			submodule.State = SubmoduleUninitialized
+		case '+':
+			submodule.State = SubmoduleOutOfSync
+		case 'U':
+			submodule.State = SubmoduleConflict
+		default:
+			submodule.State = SubmoduleInSync
+		}
+		submodules = append(submodules, submodule)
+	}
+	return submodules
+}
@@ -0,0 +1,22 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSubmodules(t *testing.T) {
+	output := " aaaa lib/in-sync (v1.0)\n" +
+		"-bbbb lib/uninitialized\n" +
+		"+cccc lib/out-of-sync (heads/main)\n" +
+		"Udddd lib/conflict\n"
+	want := []Submodule{
+		{Commit: "aaaa", Path: "lib/in-sync", State: SubmoduleInSync},
+		{Commit: "bbbb", Path: "lib/uninitialized", State: SubmoduleUninitialized},
+		{Commit: "cccc", Path: "lib/out-of-sync", State: SubmoduleOutOfSync},
+		{Commit: "dddd", Path: "lib/conflict", State: SubmoduleConflict},
+	}
+	if got := parseSubmodules(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSubmodules() = %+v, want %+v", got, want)
+	}
+}
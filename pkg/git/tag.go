@@ -0,0 +1,52 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// TagOptions control how a tag is created.
+type TagOptions struct {
+	Message string
+	// Sign creates a GPG-signed tag, instead of an annotated tag.
+	Sign bool
+}
+
+// CreateTag creates an annotated, or signed, tag on HEAD.
+func (g *Shell) CreateTag(ctx context.Context, path, name string, opts TagOptions) (string, error) {
+	args := []string{"tag", "--annotate"}
+	if opts.Sign {
+		args = []string{"tag", "--sign"}
+	}
+	args = append(args, "--message", opts.Message, name)
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to create tag: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// TagCommit returns the commit hash a tag points to, or an empty string if
+// the tag does not exist.
+func (g *Shell) TagCommit(ctx context.Context, path, name string) (string, error) {
+	args := []string{"rev-parse", "--verify", "--quiet", "refs/tags/" + name + "^{commit}"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil && len(output) == 0 && ctx.Err() == nil {
+		// Quiet verification fails silently on missing tags.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to find tag: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// PushTag pushes a single tag to remote.
+func (g *Shell) PushTag(ctx context.Context, path, remote, name string) (string, error) {
+	args := []string{"push", remote, "refs/tags/" + name}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to push tag: %w", err)
+	}
+	return cleanOutput(output), nil
+}
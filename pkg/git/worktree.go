@@ -0,0 +1,98 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Worktree is a working tree attached to a repository.
+type Worktree struct {
+	Path     string
+	Commit   string
+	Branch   string
+	Main     bool
+	Bare     bool
+	Detached bool
+	Locked   bool
+	Prunable bool
+}
+
+// AddWorktree creates a linked worktree at dest with branch checked out. When
+// base is set, the branch is created from base, without tracking it.
+func (g *Shell) AddWorktree(ctx context.Context, path, dest, branch, base string) (string, error) {
+	args := []string{"worktree", "add"}
+	if base != "" {
+		args = append(args, "--no-track", "-b", branch, dest, base)
+	} else {
+		args = append(args, dest, branch)
+	}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to add worktree: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// Worktrees returns all worktrees of a repository, the main one first.
+func (g *Shell) Worktrees(ctx context.Context, path string) ([]Worktree, error) {
+	args := []string{"worktree", "list", "--porcelain"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list worktrees: %w", err)
+	}
+	return parseWorktrees(string(output)), nil
+}
+
+// RemoveWorktree removes a linked worktree, git refuses to remove worktrees
+// with local modifications.
+func (g *Shell) RemoveWorktree(ctx context.Context, path, dest string) (string, error) {
+	args := []string{"worktree", "remove", dest}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to remove worktree: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// RepairWorktrees repairs the links between a moved repository and its
+// worktrees.
+func (g *Shell) RepairWorktrees(ctx context.Context, path string) (string, error) {
+	args := []string{"worktree", "repair"}
+	output, err := g.Exec(ctx, path, args)
+	if err != nil {
+		return "", fmt.Errorf("unable to repair worktrees: %w", err)
+	}
+	return cleanOutput(output), nil
+}
+
+// parseWorktrees parses the output of 'git worktree list --porcelain'.
+func parseWorktrees(output string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(output, "\n\n") {
+		worktree := Worktree{Main: len(worktrees) == 0}
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				worktree.Path = value
+			case "HEAD":
+				worktree.Commit = value
+			case "branch":
+				worktree.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				worktree.Bare = true
+			case "detached":
+				worktree.Detached = true
+			case "locked":
+				worktree.Locked = true
+			case "prunable":
+				worktree.Prunable = true
+			}
+		}
+		if worktree.Path != "" {
+			worktrees = append(worktrees, worktree)
+		}
+	}
+	return worktrees
+}
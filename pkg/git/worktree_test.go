@@ -0,0 +1,23 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	output := "worktree /code/api\nHEAD aaaa\nbranch refs/heads/main\n\n" +
+		"worktree /code/acme@feat-x/api\nHEAD bbbb\nbranch refs/heads/feat-x\nlocked\n\n" +
+		"worktree /tmp/gone\nHEAD cccc\ndetached\nprunable gitdir file points to non-existent location\n"
+	want := []Worktree{
+		{Path: "/code/api", Commit: "aaaa", Branch: "main", Main: true},
+		{Path: "/code/acme@feat-x/api", Commit: "bbbb", Branch: "feat-x", Locked: true},
+		{Path: "/tmp/gone", Commit: "cccc", Detached: true, Prunable: true},
+	}
+	if got := parseWorktrees(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorktrees() = %+v, want %+v", got, want)
+	}
+	if got := parseWorktrees(""); got != nil {
+		t.Errorf("parseWorktrees(\"\") = %+v, want nil", got)
+	}
+}
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -38,7 +39,7 @@ func newBitbucketProvider(token string) (*bitbucketProvider, error) {
 	return provider, nil
 }
 
-func (c *bitbucketProvider) LoadRepos(ownerName string, _ git.Git, project *domain.Project) error {
+func (c *bitbucketProvider) LoadRepos(_ context.Context, ownerName string, _ git.Git, project *domain.Project) error {
 	var err error
 	project.Repos, project.ID, err = c.fetchRepos(ownerName)
 	if err != nil {
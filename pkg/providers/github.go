@@ -40,8 +40,8 @@ func newGitHubProvider(token string) (*gitHubProvider, error) {
 	return provider, nil
 }
 
-func (c *gitHubProvider) LoadRepos(ownerName string, _ git.Git, project *domain.Project) (err error) {
-	project.Repos, project.ID, err = c.fetchRepos(ownerName)
+func (c *gitHubProvider) LoadRepos(ctx context.Context, ownerName string, _ git.Git, project *domain.Project) (err error) {
+	project.Repos, project.ID, err = c.fetchRepos(ctx, ownerName)
 	if err != nil {
 		return err
 	}
@@ -51,7 +51,7 @@ func (c *gitHubProvider) LoadRepos(ownerName string, _ git.Git, project *domain.
 	return nil
 }
 
-func (c *gitHubProvider) fetchRepos(ownerName string) ([]domain.Repository, string, error) {
+func (c *gitHubProvider) fetchRepos(ctx context.Context, ownerName string) ([]domain.Repository, string, error) {
 	var q struct {
 		Search struct {
 			Edges []struct {
@@ -67,6 +67,10 @@ func (c *gitHubProvider) fetchRepos(ownerName string) ([]domain.Repository, stri
 						URL         githubv4.String
 						SSHURL      githubv4.String
 						IsArchived  githubv4.Boolean
+						IsFork      githubv4.Boolean
+						Parent      struct {
+							SSHURL githubv4.String
+						}
 					} `graphql:"... on Repository"`
 				}
 			}
@@ -89,7 +93,6 @@ func (c *gitHubProvider) fetchRepos(ownerName string) ([]domain.Repository, stri
 
 	ownerID := ""
 	repos := []domain.Repository{}
-	ctx := context.Background()
 	pageNum := 0
 	for {
 		pageNum++
@@ -112,14 +115,20 @@ func (c *gitHubProvider) fetchRepos(ownerName string) ([]domain.Repository, stri
 			if repo.IsArchived {
 				continue
 			}
-			repos = append(repos, domain.Repository{
+			r := domain.Repository{
 				ID:        string(repo.ID),
 				Name:      string(repo.Name),
 				Namespace: string(repo.Owner.Login),
 				Src:       string(repo.SSHURL),
 				URL:       string(repo.URL),
 				Desc:      string(repo.Description),
-			})
+			}
+			if repo.IsFork && repo.Parent.SSHURL != "" {
+				r.Remotes = map[string]string{
+					domain.RemoteUpstream: string(repo.Parent.SSHURL),
+				}
+			}
+			repos = append(repos, r)
 		}
 		if !q.Search.PageInfo.HasNextPage {
 			break
@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"testing"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestGitLabForkUpstream(t *testing.T) {
+	tests := []struct {
+		name    string
+		project *gitlab.Project
+		want    string
+	}{
+		{
+			name:    "not a fork",
+			project: &gitlab.Project{SSHURLToRepo: "git@gitlab.com:me/api.git", PathWithNamespace: "me/api"},
+			want:    "",
+		},
+		{
+			name: "scp-like",
+			project: &gitlab.Project{
+				SSHURLToRepo:      "git@gitlab.com:me/api.git",
+				PathWithNamespace: "me/api",
+				ForkedFromProject: &gitlab.ForkParent{
+					PathWithNamespace: "acme/backend/api",
+					HTTPURLToRepo:     "https://gitlab.com/acme/backend/api.git",
+				},
+			},
+			want: "git@gitlab.com:acme/backend/api.git",
+		},
+		{
+			name: "ssh with port",
+			project: &gitlab.Project{
+				SSHURLToRepo:      "ssh://git@gitlab.example.com:2222/me/api.git",
+				PathWithNamespace: "me/api",
+				ForkedFromProject: &gitlab.ForkParent{PathWithNamespace: "acme/api"},
+			},
+			want: "ssh://git@gitlab.example.com:2222/acme/api.git",
+		},
+		{
+			name: "unexpected ssh url",
+			project: &gitlab.Project{
+				SSHURLToRepo:      "git@gitlab.com:renamed/api.git",
+				PathWithNamespace: "me/api",
+				ForkedFromProject: &gitlab.ForkParent{
+					PathWithNamespace: "acme/api",
+					HTTPURLToRepo:     "https://gitlab.com/acme/api.git",
+				},
+			},
+			want: "https://gitlab.com/acme/api.git",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gitLabForkUpstream(tt.project); got != tt.want {
+				t.Errorf("gitLabForkUpstream() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
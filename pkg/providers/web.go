@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ProviderGitea is only detected from web URLs, repositories can't be loaded
+// from Gitea.
+const ProviderGitea Provider = "gitea"
+
+// Page is a repository web page.
+type Page string
+
+const (
+	PageHome    Page = ""
+	PageBranch  Page = "branch"
+	PageCommits Page = "commits"
+	PagePulls   Page = "prs"
+	PageCI      Page = "ci"
+)
+
+// pagePaths are page paths relative to the repository web URL, by provider.
+// Branch pages are formatted with the branch name.
+var pagePaths = map[Provider]map[Page]string{
+	ProviderGitHub: {
+		PageBranch:  "/tree/%s",
+		PageCommits: "/commits/%s",
+		PagePulls:   "/pulls",
+		PageCI:      "/actions",
+	},
+	ProviderGitLab: {
+		PageBranch:  "/-/tree/%s",
+		PageCommits: "/-/commits/%s",
+		PagePulls:   "/-/merge_requests",
+		PageCI:      "/-/pipelines",
+	},
+	ProviderBitbucket: {
+		PageBranch:  "/src/%s",
+		PageCommits: "/commits/branch/%s",
+		PagePulls:   "/pull-requests",
+		PageCI:      "/pipelines",
+	},
+	ProviderGitea: {
+		PageBranch:  "/src/branch/%s",
+		PageCommits: "/commits/branch/%s",
+		PagePulls:   "/pulls",
+		PageCI:      "/actions",
+	},
+}
+
+// scpLikeURL matches ssh remotes in the scp form, e.g. git@host:owner/repo.
+var scpLikeURL = regexp.MustCompile(`^(?:[\w.+-]+@)?([\w.-]{2,}):(.+)$`)
+
+// WebURL returns the web URL of a repository from its ssh or https remote
+// URL. The port of https remotes is kept, ssh ports aren't web ports.
+func WebURL(remote string) (string, error) {
+	remote = strings.TrimSpace(remote)
+	scheme, host, repoPath := "https", "", ""
+	if u, err := url.Parse(remote); err == nil && u.Host != "" {
+		switch u.Scheme {
+		case "http", "https":
+			scheme, host = u.Scheme, u.Host
+		case "ssh", "git", "git+ssh":
+			host = u.Hostname()
+		default:
+			return "", fmt.Errorf("no web URL for remote %q", remote)
+		}
+		repoPath = u.Path
+	} else if m := scpLikeURL.FindStringSubmatch(remote); m != nil && !strings.Contains(remote, "://") {
+		host, repoPath = m[1], m[2]
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" {
+		return "", fmt.Errorf("no web URL for remote %q", remote)
+	}
+	return scheme + "://" + host + "/" + repoPath, nil
+}
+
+// DetectProvider returns the provider of a web URL by its host name, or an
+// empty provider if unknown.
+func DetectProvider(webURL string) Provider {
+	u, err := url.Parse(webURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(host, "github"):
+		return ProviderGitHub
+	case strings.Contains(host, "gitlab"):
+		return ProviderGitLab
+	case strings.Contains(host, "bitbucket"):
+		return ProviderBitbucket
+	case strings.Contains(host, "gitea"), strings.Contains(host, "codeberg"):
+		return ProviderGitea
+	}
+	return ""
+}
+
+// PageURL returns the URL of a repository page. Branch is required for the
+// branch page, and optional for commits.
+func PageURL(webURL string, provider Provider, page Page, branch string) (string, error) {
+	if page == PageHome {
+		return webURL, nil
+	}
+	paths, found := pagePaths[provider]
+	if !found {
+		return "", fmt.Errorf("unknown provider of %s, unable to find %s page", webURL, page)
+	}
+	pagePath := paths[page]
+	if strings.Contains(pagePath, "%s") {
+		if branch == "" && page == PageBranch {
+			return "", fmt.Errorf("missing branch for %s page", page)
+		}
+		segments := strings.Split(branch, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		pagePath = fmt.Sprintf(pagePath, strings.Join(segments, "/"))
+		// Without a branch, link to the commits of the default branch.
+		pagePath = strings.TrimSuffix(strings.TrimSuffix(pagePath, "/"), "/branch")
+	}
+	return webURL + pagePath, nil
+}